@@ -3,10 +3,10 @@ package cmd
 import (
 	"fmt"
 	"sort"
-	"strconv"
 	"strigo/config"
 	"strigo/logging"
 	"strigo/repository"
+	sdkversion "strigo/version"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -14,10 +14,17 @@ import (
 
 // Structures pour la sortie JSON
 type AvailableOutput struct {
-	Types         []string              `json:"types,omitempty"`
-	Distributions []string              `json:"distributions,omitempty"`
-	Versions      []repository.SDKAsset `json:"versions,omitempty"`
-	Error         string                `json:"error,omitempty"`
+	Types         []string               `json:"types,omitempty"`
+	Distributions []string               `json:"distributions,omitempty"`
+	Versions      []repository.SDKAsset  `json:"versions,omitempty"`
+	Matches       []repository.PathMatch `json:"matches,omitempty"`
+	Error         string                 `json:"error,omitempty"`
+}
+
+var availableDryRun bool
+
+func init() {
+	availableCmd.Flags().BoolVar(&availableDryRun, "dry-run", false, "Show which registry paths each filename pattern matched or ignored")
 }
 
 // availableCmd represents the available command
@@ -29,7 +36,8 @@ Examples:
   strigo available                  # List all available SDK types
   strigo available jdk             # List all available JDK distributions
   strigo available jdk temurin     # List all Temurin JDK versions
-  strigo available jdk temurin 11  # List Temurin JDK versions containing "11"`,
+  strigo available jdk temurin 11  # List Temurin JDK versions containing "11"
+  strigo available jdk temurin --dry-run  # Show how each registry path was matched`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		// Charger la configuration avant la validation
 		var err error
@@ -92,6 +100,10 @@ Examples:
 			versionFilter = args[2]
 		}
 
+		if availableDryRun {
+			return handleDryRun(distribution, output)
+		}
+
 		return handleFullCommand(sdkType, distribution, versionFilter, output)
 	},
 }
@@ -137,6 +149,10 @@ func handleNoArgs(output *AvailableOutput) error {
 	types := getValidSDKTypes()
 	output.Types = types
 
+	if jsonOutput {
+		return OutputJSON(output)
+	}
+
 	if len(types) > 0 {
 		logging.LogOutput("Available SDK types:")
 		logging.LogOutput("─────────────────────")
@@ -156,6 +172,11 @@ func handleTypeOnly(sdkType string, output *AvailableOutput) error {
 			output.Distributions = append(output.Distributions, name)
 		}
 	}
+	sort.Strings(output.Distributions)
+
+	if jsonOutput {
+		return OutputJSON(output)
+	}
 
 	if len(output.Distributions) > 0 {
 		logging.LogOutput("Available %s distributions:", sdkType)
@@ -167,43 +188,6 @@ func handleTypeOnly(sdkType string, output *AvailableOutput) error {
 	return nil
 }
 
-// ExtractMajorVersion extrait la version majeure d'une chaîne de version
-func ExtractMajorVersion(version string) string {
-	logging.LogDebug("Extracting major version from: %s", version)
-
-	// Si la version est vide, retourner vide
-	if version == "" {
-		logging.LogDebug("Empty version string")
-		return ""
-	}
-
-	// Pour les versions Node.js qui commencent directement par un nombre (22.13.1)
-	if firstDot := strings.Index(version, "."); firstDot != -1 {
-		majorPart := version[:firstDot]
-		if _, err := strconv.Atoi(majorPart); err == nil {
-			logging.LogDebug("Found major version (direct number): %s", majorPart)
-			return majorPart
-		}
-	}
-
-	// Supprimer tout préfixe non numérique (comme "jdk-")
-	version = strings.TrimLeft(version, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-_")
-
-	// Trouver le premier nombre qui représente la version majeure
-	parts := strings.Split(version, ".")
-	if len(parts) > 0 {
-		// Pour les versions comme "8u442b06", extraire le 8
-		majorPart := strings.Split(parts[0], "u")[0]
-		if _, err := strconv.Atoi(majorPart); err == nil {
-			logging.LogDebug("Found major version (after cleanup): %s", majorPart)
-			return majorPart
-		}
-	}
-
-	logging.LogDebug("No major version found")
-	return ""
-}
-
 func handleFullCommand(sdkType, distribution, versionFilter string, output *AvailableOutput) error {
 	// Check if the distribution exists
 	sdkRepo, exists := cfg.SDKRepositories[distribution]
@@ -231,30 +215,19 @@ func handleFullCommand(sdkType, distribution, versionFilter string, output *Avai
 	logging.LogDebug("Found %d versions before filtering", len(versions))
 
 	// Collecter toutes les versions majeures disponibles
-	allMajorVersions := make(map[string]bool)
-	for _, v := range versions {
-		logging.LogDebug("Version before filtering: %s", v.Version)
-		majorVersion := ExtractMajorVersion(v.Version)
-		if majorVersion != "" {
-			allMajorVersions[majorVersion] = true
+	var availableMajors []string
+	for _, group := range sdkversion.GroupByMajor(assetVersions(versions)) {
+		if group.Major != "unknown" {
+			availableMajors = append(availableMajors, group.Major)
 		}
 	}
 
-	// Convertir en slice et trier
-	var availableMajors []int
-	for major := range allMajorVersions {
-		if num, err := strconv.Atoi(major); err == nil {
-			availableMajors = append(availableMajors, num)
-		}
-	}
-	sort.Ints(availableMajors)
-
 	// Filtrer les versions si un filtre est spécifié
 	if versionFilter != "" {
 		var filteredVersions []repository.SDKAsset
 		for _, v := range versions {
 			logging.LogDebug("Checking version %s against filter %s", v.Version, versionFilter)
-			if ExtractMajorVersion(v.Version) == versionFilter {
+			if sdkversion.Parse(v.Version).MajorString() == versionFilter {
 				logging.LogDebug("  ✓ Version matches filter")
 				filteredVersions = append(filteredVersions, v)
 			} else {
@@ -266,7 +239,7 @@ func handleFullCommand(sdkType, distribution, versionFilter string, output *Avai
 		if len(filteredVersions) == 0 {
 			logging.LogOutput("❌ No version found matching major version %s", versionFilter)
 			logging.LogOutput("")
-			logging.LogOutput("💡 Available major versions are: %s", joinInts(availableMajors))
+			logging.LogOutput("💡 Available major versions are: %s", strings.Join(availableMajors, ", "))
 			return nil
 		}
 
@@ -275,93 +248,113 @@ func handleFullCommand(sdkType, distribution, versionFilter string, output *Avai
 	}
 
 	// Trier les versions
-	sort.Slice(versions, func(i, j int) bool {
-		return repository.CompareVersions(versions[i].Version, versions[j].Version)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return sdkversion.Less(versions[i].Version, versions[j].Version)
 	})
 
 	output.Versions = versions
 
+	if jsonOutput {
+		return OutputJSON(output)
+	}
+
 	displayVersions(versions, sdkType, distribution)
 	return nil
 }
 
-// joinInts convertit une slice d'entiers en chaîne de caractères
-func joinInts(numbers []int) string {
-	var strNumbers []string
-	for _, num := range numbers {
-		strNumbers = append(strNumbers, strconv.Itoa(num))
+// handleDryRun shows which paths were matched by which pattern, and which were ignored
+func handleDryRun(distribution string, output *AvailableOutput) error {
+	sdkRepo, exists := cfg.SDKRepositories[distribution]
+	if !exists {
+		return fmt.Errorf("distribution %s not found in configuration", distribution)
+	}
+
+	registry, exists := cfg.Registries[sdkRepo.Registry]
+	if !exists {
+		return fmt.Errorf("registry %s not found in configuration", sdkRepo.Registry)
 	}
-	return strings.Join(strNumbers, ", ")
+
+	matches, err := repository.ExplainAvailableVersions(sdkRepo, registry)
+	if err != nil {
+		return err
+	}
+	output.Matches = matches
+
+	if jsonOutput {
+		return OutputJSON(output)
+	}
+
+	var matched, ignored []repository.PathMatch
+	for _, match := range matches {
+		if match.Ignored != "" {
+			ignored = append(ignored, match)
+		} else {
+			matched = append(matched, match)
+		}
+	}
+
+	logging.LogOutput("🔍 Matched paths (%d):", len(matched))
+	logging.LogOutput("─────────────────────────")
+	for _, match := range matched {
+		logging.LogOutput("✅ %s", match.Path)
+		logging.LogOutput("    version=%s os=%s arch=%s", match.Version, match.OS, match.Arch)
+		logging.LogOutput("    pattern: %s", match.Pattern)
+	}
+	logging.LogOutput("")
+
+	logging.LogOutput("❌ Ignored paths (%d):", len(ignored))
+	logging.LogOutput("─────────────────────────")
+	for _, match := range ignored {
+		logging.LogOutput("   %s (%s)", match.Path, match.Ignored)
+	}
+	return nil
+}
+
+// assetVersions retourne les versions des assets
+func assetVersions(assets []repository.SDKAsset) []string {
+	var versions []string
+	for _, asset := range assets {
+		versions = append(versions, asset.Version)
+	}
+	return versions
 }
 
 func displayVersions(versions []repository.SDKAsset, sdkType, distribution string) {
 	logging.LogDebug("Processing %d versions for display", len(versions))
 
-	// Grouper les versions par version majeure
-	versionGroups := make(map[string][]string)
-	allMajorVersions := make(map[string]bool)
-
-	// Récupérer toutes les versions majeures disponibles
+	// Plateformes disponibles pour chaque version
+	platforms := make(map[string][]string)
+	var unique []string
 	for _, asset := range versions {
-		logging.LogDebug("Processing version: %s", asset.Version)
-		majorVersion := ExtractMajorVersion(asset.Version)
-		logging.LogDebug("  Extracted major version: %s", majorVersion)
-		if majorVersion != "" {
-			allMajorVersions[majorVersion] = true
-			versionGroups[majorVersion] = append(versionGroups[majorVersion], asset.Version)
-			logging.LogDebug("  Added to version groups. Current groups: %v", versionGroups)
+		if _, seen := platforms[asset.Version]; !seen {
+			unique = append(unique, asset.Version)
+			platforms[asset.Version] = nil
 		}
-	}
-
-	// Obtenir les versions majeures triées
-	var majorVersions []int
-	for major := range versionGroups {
-		if num, err := strconv.Atoi(major); err == nil {
-			majorVersions = append(majorVersions, num)
+		if asset.OS != "" || asset.Arch != "" {
+			platforms[asset.Version] = append(platforms[asset.Version], asset.Platform())
 		}
 	}
-	sort.Ints(majorVersions)
 
 	logging.LogOutput("🔹 Available versions:")
 	logging.LogOutput("─────────────────────────")
 
 	// Si aucune version n'est trouvée
-	if len(majorVersions) == 0 {
+	groups := sdkversion.GroupByMajor(unique)
+	if len(groups) == 0 {
 		logging.LogOutput("❌ No major version found matching your criteria")
-		logging.LogOutput("")
-
-		// Créer la liste des versions majeures disponibles
-		var availableMajors []int
-		for major := range allMajorVersions {
-			if num, err := strconv.Atoi(major); err == nil {
-				availableMajors = append(availableMajors, num)
-			}
-		}
-		sort.Ints(availableMajors)
-
-		// Convertir les versions en chaînes pour l'affichage
-		var majorStrings []string
-		for _, num := range availableMajors {
-			majorStrings = append(majorStrings, strconv.Itoa(num))
-		}
-
-		logging.LogOutput("💡 Available major versions are: %s", strings.Join(majorStrings, ", "))
 		return
 	}
 
-	// Afficher les versions par groupe
-	for _, majorNum := range majorVersions {
-		major := strconv.Itoa(majorNum)
-		versions := versionGroups[major]
-
-		// Trier les versions dans chaque groupe
-		sort.Slice(versions, func(i, j int) bool {
-			return repository.CompareVersions(versions[i], versions[j])
-		})
-
-		logging.LogOutput("-%d :", majorNum)
-		for _, version := range versions {
-			logging.LogOutput("    ✅ %s", version)
+	// Afficher les versions par groupe, de la plus ancienne à la plus récente
+	for _, group := range groups {
+		logging.LogOutput("-%s :", group.Major)
+		for _, version := range group.Versions {
+			if len(platforms[version]) > 0 {
+				sort.Strings(platforms[version])
+				logging.LogOutput("    ✅ %s (%s)", version, strings.Join(platforms[version], ", "))
+			} else {
+				logging.LogOutput("    ✅ %s", version)
+			}
 		}
 		logging.LogOutput("") // Ligne vide entre les groupes
 	}
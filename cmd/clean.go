@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strigo/logging"
 	"strings"
 
@@ -80,27 +79,25 @@ func handleClean() error {
 }
 
 func cleanJavaHome() error {
-	// Déterminer le shell de l'utilisateur
-	shell := os.Getenv("SHELL")
-	var rcFile string
-
-	switch {
-	case strings.HasSuffix(shell, "bash"):
-		rcFile = filepath.Join(os.Getenv("HOME"), ".bashrc")
-	case strings.HasSuffix(shell, "zsh"):
-		rcFile = filepath.Join(os.Getenv("HOME"), ".zshrc")
-	default:
-		return fmt.Errorf("unsupported shell: %s. Please clean JAVA_HOME manually", shell)
+	// Déterminer le fichier de configuration du shell de l'utilisateur
+	rcFile, _, err := findRcFile()
+	if err != nil {
+		return fmt.Errorf("%w. Please clean JAVA_HOME manually", err)
 	}
 
 	// Lire le contenu actuel
 	content, err := os.ReadFile(rcFile)
-	if err != nil && !os.IsNotExist(err) {
+	if os.IsNotExist(err) {
+		logging.LogInfo("ℹ️  No JAVA_HOME configuration found in %s", rcFile)
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("failed to read rc file: %w", err)
 	}
 
-	// Supprimer les lignes JAVA_HOME
-	lines := strings.Split(string(content), "\n")
+	// Supprimer le bloc Strigo puis les lignes JAVA_HOME restantes
+	cleaned, _ := removeRcBlock(string(content), "jdk")
+	lines := strings.Split(cleaned, "\n")
 	var newLines []string
 	for _, line := range lines {
 		if !strings.Contains(line, "JAVA_HOME=") && !strings.Contains(line, "PATH=$JAVA_HOME") {
@@ -115,6 +112,7 @@ func cleanJavaHome() error {
 	}
 
 	logging.LogInfo("✅ Successfully removed JAVA_HOME configuration")
+	warnLeftoverHomeLines(newContent, "JAVA_HOME", rcFile)
 	logging.LogInfo("ℹ️  Please run 'source %s' to apply the changes", rcFile)
 
 	return nil
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strigo/installation"
+	"strigo/project"
+	sdkversion "strigo/version"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var currentCmd = &cobra.Command{
+	Use:   "current [type]",
+	Short: "Show the SDK versions active in the current directory",
+	Long: `Show the SDK versions active in the current directory and why: a version pinned
+by a project file (.strigo.toml, .tool-versions, .sdkmanrc, .java-version, .nvmrc,
+.node-version) found in this directory or a parent, else the global version set
+with 'strigo use'.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  current,
+	Example: `  # Show every active SDK
+  strigo current
+
+  # Show the active JDK only, as JSON
+  strigo current jdk --json`,
+}
+
+// Sources of an active SDK version besides a pin file
+const (
+	sourceGlobal = "global"
+	sourceNone   = "none"
+)
+
+// activeSDK describes the SDK version in effect for a type
+type activeSDK struct {
+	SDKType      string `json:"sdk_type"`
+	Distribution string `json:"distribution,omitempty"`
+	Version      string `json:"version,omitempty"`   // Installed version, empty when not installed
+	Requested    string `json:"requested,omitempty"` // Version expression of the pin
+	Home         string `json:"home,omitempty"`
+	Source       string `json:"source"` // Pin file, "global" for the current-<type> link, or "none"
+	Installed    bool   `json:"installed"`
+}
+
+func current(cmd *cobra.Command, args []string) {
+	if err := handleCurrent(args); err != nil {
+		ExitWithError(err)
+	}
+}
+
+func handleCurrent(args []string) error {
+	var sdkTypes []string
+	if len(args) == 1 {
+		if _, exists := cfg.SDKTypes[args[0]]; !exists {
+			return fmt.Errorf("SDK type %s not found in configuration", args[0])
+		}
+		sdkTypes = args
+	} else {
+		for sdkType := range cfg.SDKTypes {
+			sdkTypes = append(sdkTypes, sdkType)
+		}
+		sort.Strings(sdkTypes)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	var actives []*activeSDK
+	for _, sdkType := range sdkTypes {
+		active, err := findActiveSDK(dir, sdkType)
+		if err != nil {
+			return err
+		}
+		actives = append(actives, active)
+	}
+
+	if jsonOutput {
+		return OutputJSON(actives)
+	}
+
+	for _, active := range actives {
+		switch {
+		case active.Source == sourceNone:
+			fmt.Printf("➖ %s: no version selected\n", active.SDKType)
+		case !active.Installed:
+			fmt.Printf("❌ %s: %s %s is not installed\n", active.SDKType, active.Distribution, active.Requested)
+			fmt.Printf("    pinned by %s\n", active.Source)
+			fmt.Printf("    💡 Run 'strigo install' to install the pinned versions\n")
+		case active.Source == sourceGlobal:
+			fmt.Printf("✅ %s: %s %s\n", active.SDKType, active.Distribution, active.Version)
+			fmt.Printf("    set globally by 'strigo use' (%s)\n", active.Home)
+		default:
+			fmt.Printf("✅ %s: %s %s\n", active.SDKType, active.Distribution, active.Version)
+			fmt.Printf("    pinned by %s (%s)\n", active.Source, active.Requested)
+		}
+	}
+	return nil
+}
+
+// findActiveSDK returns the version of an SDK type in effect for dir: the
+// nearest pin, else the global current-<type> link
+func findActiveSDK(dir, sdkType string) (*activeSDK, error) {
+	pin, err := project.FindType(dir, sdkType)
+	if err != nil {
+		return nil, err
+	}
+	if pin != nil {
+		return resolvePin(*pin)
+	}
+	return globalSDK(sdkType), nil
+}
+
+// resolvePin finds the installed version satisfying a pin
+func resolvePin(pin project.Pin) (*activeSDK, error) {
+	sdkTypeConfig, exists := cfg.SDKTypes[pin.SDKType]
+	if !exists {
+		return nil, fmt.Errorf("SDK type %s pinned by %s not found in configuration", pin.SDKType, pin.Source)
+	}
+
+	distribution, err := pinDistribution(pin)
+	if err != nil {
+		return nil, err
+	}
+
+	active := &activeSDK{
+		SDKType:      pin.SDKType,
+		Distribution: distribution,
+		Requested:    pin.Version,
+		Source:       pin.Source,
+	}
+
+	distributionPath := filepath.Join(cfg.General.SDKInstallDir, sdkTypeConfig.InstallDir, distribution)
+	version, err := sdkversion.Resolve(pin.Version, visibleSubdirectories(distributionPath), sdkTypeConfig.LTSMajors())
+	if err != nil {
+		return active, nil
+	}
+
+	home, err := installation.Home(filepath.Join(distributionPath, version), pin.SDKType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find SDK home of %s %s %s: %w", pin.SDKType, distribution, version, err)
+	}
+	active.Version = version
+	active.Home = home
+	active.Installed = true
+	return active, nil
+}
+
+// pinDistribution returns the distribution of a pin, defaulting to the only
+// distribution configured for its SDK type
+func pinDistribution(pin project.Pin) (string, error) {
+	sdkType := cfg.SDKTypes[pin.SDKType].Type
+
+	if pin.Distribution != "" {
+		sdkRepo, exists := cfg.SDKRepositories[pin.Distribution]
+		if !exists || sdkRepo.Type != sdkType {
+			return "", fmt.Errorf("distribution %s pinned by %s not found in configuration for %s", pin.Distribution, pin.Source, pin.SDKType)
+		}
+		return pin.Distribution, nil
+	}
+
+	var candidates []string
+	for name, sdkRepo := range cfg.SDKRepositories {
+		if sdkRepo.Type == sdkType {
+			candidates = append(candidates, name)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no distribution configured for %s pinned by %s", pin.SDKType, pin.Source)
+	case 1:
+		return candidates[0], nil
+	}
+	sort.Strings(candidates)
+	return "", fmt.Errorf("%s does not name a %s distribution and several are configured (%s): name one in %s",
+		pin.Source, pin.SDKType, strings.Join(candidates, ", "), project.FileName)
+}
+
+// globalSDK reads the version selected by 'strigo use' from the current-<type> link
+func globalSDK(sdkType string) *activeSDK {
+	active := &activeSDK{SDKType: sdkType, Source: sourceNone}
+
+	linkPath := filepath.Join(cfg.General.SDKInstallDir, fmt.Sprintf("current-%s", sdkType))
+	home, err := os.Readlink(linkPath)
+	if err != nil {
+		return active
+	}
+	if _, err := os.Stat(home); err != nil {
+		return active
+	}
+
+	active.Source = sourceGlobal
+	active.Home = home
+	active.Installed = true
+
+	// The link targets the SDK home inside <install dir>/<distribution>/<version>
+	typePath := filepath.Join(cfg.General.SDKInstallDir, cfg.SDKTypes[sdkType].InstallDir)
+	if rel, err := filepath.Rel(typePath, home); err == nil {
+		parts := strings.Split(rel, string(filepath.Separator))
+		if len(parts) >= 2 && parts[0] != ".." {
+			active.Distribution = parts[0]
+			active.Version = parts[1]
+		}
+	}
+	return active
+}
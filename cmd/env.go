@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strigo/logging"
+	"strigo/project"
+
+	"github.com/spf13/cobra"
+)
+
+var envShell string
+
+func init() {
+	envCmd.Flags().StringVar(&envShell, "shell", "", "Output format: bash, zsh, fish, nu, json, dotenv or github-actions (defaults to $SHELL)")
+}
+
+var envCmd = &cobra.Command{
+	Use:   "env [type:[distribution:]version...]",
+	Short: "Print the environment of the selected SDK versions",
+	Long: `Print the variables needed to use SDK versions (JAVA_HOME, NODE_HOME, the env
+variables of each SDK type and PATH), for a shell to evaluate or a file to load.
+Without arguments, the versions active in the current directory are used: those
+pinned by the project, else those set with 'strigo use'.`,
+	Annotations: map[string]string{annotationMachineOutput: "true"},
+	Run:         env,
+	Example: `  # Load the selected SDKs in the current shell
+  eval "$(strigo env)"
+
+  # Write a .env file for Temurin 17
+  strigo env jdk:temurin:17 --shell dotenv > .env
+
+  # Use the pinned versions in the next GitHub Actions steps
+  strigo env --shell github-actions >> "$GITHUB_ENV"`,
+}
+
+func env(cmd *cobra.Command, args []string) {
+	if err := handleEnv(args); err != nil {
+		ExitWithError(err)
+	}
+}
+
+func handleEnv(specs []string) error {
+	shell := envShell
+	if shell == "" {
+		shell = defaultShell()
+	}
+	syntax, err := lookupShell(shell)
+	if err != nil {
+		return err
+	}
+
+	var actives []*activeSDK
+	if len(specs) > 0 {
+		actives, err = resolveSDKSpecs(specs)
+	} else {
+		actives, err = selectedSDKs()
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(syntax.render(sdkEnvironment(actives)))
+	return nil
+}
+
+// selectedSDKs returns the installed SDK versions active in the current directory
+func selectedSDKs() ([]*activeSDK, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	var sdkTypes []string
+	for sdkType := range cfg.SDKTypes {
+		sdkTypes = append(sdkTypes, sdkType)
+	}
+	sort.Strings(sdkTypes)
+
+	var actives []*activeSDK
+	for _, sdkType := range sdkTypes {
+		active, err := findActiveSDK(dir, sdkType)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case active.Source == sourceNone:
+			continue
+		case !active.Installed:
+			logging.LogInfo("⚠️ %s %s %s pinned by %s is not installed, run 'strigo install'", sdkType, active.Distribution, active.Requested, active.Source)
+			continue
+		}
+		actives = append(actives, active)
+	}
+
+	if len(actives) == 0 {
+		return nil, fmt.Errorf("no SDK version selected: run 'strigo use' or pin versions in a project file such as %s", project.FileName)
+	}
+	return actives, nil
+}
+
+// defaultShell returns the shell of the user when it is supported, else bash
+func defaultShell() string {
+	shell := filepath.Base(os.Getenv("SHELL"))
+	switch shell {
+	case "bash", "zsh", "fish", "nu":
+		return shell
+	}
+	return "bash"
+}
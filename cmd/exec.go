@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strigo/logging"
+	"strigo/project"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// exitCodeNotFound is the exit code when the command to run cannot be found, as in shells
+const exitCodeNotFound = 127
+
+var execCmd = &cobra.Command{
+	Use:   "exec [type:[distribution:]version...] -- [command] [args...]",
+	Short: "Run a command with specific SDK versions",
+	Long: `Run a command with specific installed SDK versions, without changing the versions
+selected with 'strigo use'. The command gets JAVA_HOME, NODE_HOME, the variables
+configured in the env table of each SDK type, and PATH starting with the bin/
+directories of the SDKs. It replaces strigo, so its exit code and signals are
+those of the command.
+
+Versions accept the same forms as install (21, lts, ">=17 <22"...); the
+distribution may be omitted when only one is configured for the type.`,
+	Annotations: map[string]string{annotationMachineOutput: "true"},
+	Args: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		if dash < 1 || dash == len(args) {
+			return fmt.Errorf("\n❌ Invalid arguments\n\n" +
+				"Usage:\n" +
+				"  strigo exec [type:[distribution:]version...] -- [command] [args...]\n\n" +
+				"Example:\n" +
+				"  strigo exec jdk:temurin:17 node:22 -- ./gradlew build")
+		}
+		return nil
+	},
+	Run: execCommand,
+	Example: `  # Build with Temurin 17 and Node.js 22
+  strigo exec jdk:temurin:17 node:22 -- ./gradlew build
+
+  # Run the newest installed LTS JDK
+  strigo exec jdk:lts -- java -version`,
+}
+
+func execCommand(cmd *cobra.Command, args []string) {
+	dash := cmd.ArgsLenAtDash()
+	specs, command := args[:dash], args[dash:]
+
+	actives, err := resolveSDKSpecs(specs)
+	if err != nil {
+		ExitWithError(err)
+	}
+	for _, change := range sdkEnvironment(actives) {
+		if err := os.Setenv(change.Name, change.Value); err != nil {
+			ExitWithError(fmt.Errorf("failed to set %s: %w", change.Name, err))
+		}
+	}
+
+	// Look the command up in the new PATH
+	path, err := exec.LookPath(command[0])
+	if err != nil {
+		logging.LogError("❌ %v", err)
+		os.Exit(exitCodeNotFound)
+	}
+
+	logging.LogDebug("🚀 Running %s", strings.Join(command, " "))
+	if err := syscall.Exec(path, command, os.Environ()); err != nil {
+		ExitWithError(fmt.Errorf("failed to run %s: %w", path, err))
+	}
+}
+
+// resolveSDKSpecs resolves SDK specs (type:[distribution:]version) among the installed versions
+func resolveSDKSpecs(specs []string) ([]*activeSDK, error) {
+	var actives []*activeSDK
+	seen := make(map[string]bool)
+
+	for _, spec := range specs {
+		pin, err := parseSDKSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		if seen[pin.SDKType] {
+			return nil, fmt.Errorf("%s is requested more than once", pin.SDKType)
+		}
+		seen[pin.SDKType] = true
+
+		active, err := resolvePin(pin)
+		if err != nil {
+			return nil, err
+		}
+		if !active.Installed {
+			return nil, fmt.Errorf("%s %s %s is not installed\n💡 Run 'strigo install %s %s %s'",
+				pin.SDKType, active.Distribution, pin.Version, pin.SDKType, active.Distribution, pin.Version)
+		}
+		logging.LogDebug("🎯 Using %s %s %s (%s)", active.SDKType, active.Distribution, active.Version, active.Home)
+		actives = append(actives, active)
+	}
+	return actives, nil
+}
+
+// sdkEnvironment returns the variables selecting SDKs: their home and configured
+// variables, and PATH starting with their bin/ directories in the given order
+func sdkEnvironment(actives []*activeSDK) []envChange {
+	var changes []envChange
+	var binDirs []string
+	for _, active := range actives {
+		changes = append(changes, sdkVariables(active.SDKType, active.Home)...)
+		binDirs = append(binDirs, filepath.Join(active.Home, "bin"))
+	}
+
+	path := filepath.SplitList(os.Getenv("PATH"))
+	for _, dir := range binDirs {
+		path = removePathEntry(path, dir)
+	}
+	path = append(binDirs, path...)
+	return append(changes, envChange{Name: "PATH", Value: strings.Join(path, string(os.PathListSeparator))})
+}
+
+// parseSDKSpec parses a type:[distribution:]version command line spec
+func parseSDKSpec(spec string) (project.Pin, error) {
+	parts := strings.Split(spec, ":")
+	pin := project.Pin{SDKType: parts[0], Source: spec}
+	switch len(parts) {
+	case 2:
+		pin.Version = parts[1]
+	case 3:
+		pin.Distribution = parts[1]
+		pin.Version = parts[2]
+	default:
+		return pin, fmt.Errorf("invalid SDK %q: expected type:[distribution:]version, e.g. jdk:temurin:17", spec)
+	}
+	if pin.SDKType == "" || pin.Version == "" {
+		return pin, fmt.Errorf("invalid SDK %q: expected type:[distribution:]version, e.g. jdk:temurin:17", spec)
+	}
+	return pin, nil
+}
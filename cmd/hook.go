@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strigo/logging"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Variables recording the state of the shell hook between two prompts
+const (
+	hookHomePrefix = "_STRIGO_HOME_" // SDK home applied by the hook, per SDK type
+	hookPrevPrefix = "_STRIGO_PREV_" // Value of a home variable before the hook changed it
+)
+
+var hookShell string
+
+func init() {
+	hookEnvCmd.Flags().StringVar(&hookShell, "shell", "bash", "Shell to emit commands for (bash, zsh, fish)")
+}
+
+var hookCmd = &cobra.Command{
+	Use:   "hook [bash|zsh|fish]",
+	Short: "Print a shell hook switching SDK versions per directory",
+	Long: `Print a shell hook that, whenever the current directory changes, reads the
+nearest project version file (.strigo.toml, .java-version, .nvmrc...) and exports
+JAVA_HOME, NODE_HOME and PATH for this shell only. Leaving the project restores
+the previous values. Add it to your shell configuration:
+
+  bash (~/.bashrc):                  eval "$(strigo hook bash)"
+  zsh (~/.zshrc):                    eval "$(strigo hook zsh)"
+  fish (~/.config/fish/config.fish): strigo hook fish | source`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationMachineOutput: "true"},
+	Run:         hook,
+}
+
+var hookEnvCmd = &cobra.Command{
+	Use:         "hook-env",
+	Short:       "Print the environment changes for the current directory",
+	Hidden:      true,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationMachineOutput: "true"},
+	Run:         hookEnv,
+}
+
+// hookScripts are the hooks installed in each shell, %[1]s being the quoted strigo binary
+var hookScripts = map[string]string{
+	"bash": `_strigo_hook() {
+  local previous_exit_status=$?
+  if [[ "${_STRIGO_LAST_PWD-}" != "$PWD" ]]; then
+    _STRIGO_LAST_PWD="$PWD"
+    eval "$(%[1]s hook-env --shell bash)"
+  fi
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_strigo_hook;"* ]]; then
+  PROMPT_COMMAND="_strigo_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`,
+	"zsh": `_strigo_hook() {
+  eval "$(%[1]s hook-env --shell zsh)"
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd _strigo_hook
+_strigo_hook
+`,
+	"fish": `function _strigo_hook --on-variable PWD
+    %[1]s hook-env --shell fish | source
+end
+_strigo_hook
+`,
+}
+
+func hook(cmd *cobra.Command, args []string) {
+	script, err := hookScript(args[0], executablePath())
+	if err != nil {
+		ExitWithError(err)
+	}
+	fmt.Print(script)
+}
+
+// hookScript returns the hook of a shell, calling back the strigo binary at binary
+func hookScript(shell, binary string) (string, error) {
+	script, supported := hookScripts[shell]
+	if !supported {
+		return "", fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish)", shell)
+	}
+
+	quoted := posixQuote(binary)
+	if shell == "fish" {
+		quoted = fishQuote(binary)
+	}
+	return fmt.Sprintf(script, quoted), nil
+}
+
+func hookEnv(cmd *cobra.Command, args []string) {
+	syntax, err := lookupShell(hookShell)
+	if err != nil {
+		ExitWithError(err)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		ExitWithError(fmt.Errorf("failed to get current directory: %w", err))
+	}
+
+	changes, err := hookChanges(dir)
+	if err != nil {
+		ExitWithError(err)
+	}
+	fmt.Print(syntax.render(changes))
+}
+
+// hookChanges computes the environment changes moving the shell to the SDK
+// versions pinned for dir, undoing those applied for the previous directory
+func hookChanges(dir string) ([]envChange, error) {
+	var sdkTypes []string
+	for sdkType := range cfg.SDKTypes {
+		sdkTypes = append(sdkTypes, sdkType)
+	}
+	sort.Strings(sdkTypes)
+
+	path := filepath.SplitList(os.Getenv("PATH"))
+	pathChanged := false
+
+	var changes []envChange
+	for _, sdkType := range sdkTypes {
+		active, err := findActiveSDK(dir, sdkType)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only pins are applied: outside a project the global selection stays in charge
+		home := ""
+		if active.Source != sourceGlobal && active.Source != sourceNone {
+			if !active.Installed {
+				logging.LogInfo("⚠️ %s %s %s pinned by %s is not installed, run 'strigo install'", sdkType, active.Distribution, active.Requested, active.Source)
+			}
+			home = active.Home
+		}
+
+		stateVar := hookHomePrefix + strings.ToUpper(sdkType)
+		applied := os.Getenv(stateVar)
+		if home == applied {
+			continue
+		}
+
+		homeVar := homeVariable(sdkType)
+		prevVar := hookPrevPrefix + homeVar
+
+		// Leave the previous SDK
+		if applied != "" {
+			path = removePathEntry(path, filepath.Join(applied, "bin"))
+			pathChanged = true
+			if home == "" {
+				if homeVar != "" {
+					if prev := os.Getenv(prevVar); prev != "" {
+						changes = append(changes, envChange{Name: homeVar, Value: prev})
+					} else {
+						changes = append(changes, envChange{Name: homeVar, Unset: true})
+					}
+					changes = append(changes, envChange{Name: prevVar, Unset: true})
+				}
+				changes = append(changes, envChange{Name: stateVar, Unset: true})
+				continue
+			}
+		}
+
+		// Enter the pinned SDK, remembering the value it replaces
+		if homeVar != "" {
+			if applied == "" {
+				changes = append(changes, envChange{Name: prevVar, Value: os.Getenv(homeVar)})
+			}
+			changes = append(changes, envChange{Name: homeVar, Value: home})
+		}
+		changes = append(changes, envChange{Name: stateVar, Value: home})
+		path = append([]string{filepath.Join(home, "bin")}, path...)
+		pathChanged = true
+	}
+
+	if pathChanged {
+		changes = append(changes, envChange{Name: "PATH", Value: strings.Join(path, string(os.PathListSeparator))})
+	}
+	return changes, nil
+}
+
+// removePathEntry removes the first occurrence of dir from a PATH list
+func removePathEntry(path []string, dir string) []string {
+	for i, entry := range path {
+		if entry == dir {
+			return append(path[:i:i], path[i+1:]...)
+		}
+	}
+	return path
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import "testing"
+
+func TestHookScript(t *testing.T) {
+	const binary = "/opt/my tools/it's/strigo"
+
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{
+			shell: "bash",
+			want: `_strigo_hook() {
+  local previous_exit_status=$?
+  if [[ "${_STRIGO_LAST_PWD-}" != "$PWD" ]]; then
+    _STRIGO_LAST_PWD="$PWD"
+    eval "$('/opt/my tools/it'\''s/strigo' hook-env --shell bash)"
+  fi
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_strigo_hook;"* ]]; then
+  PROMPT_COMMAND="_strigo_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`,
+		},
+		{
+			shell: "zsh",
+			want: `_strigo_hook() {
+  eval "$('/opt/my tools/it'\''s/strigo' hook-env --shell zsh)"
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd _strigo_hook
+_strigo_hook
+`,
+		},
+		{
+			shell: "fish",
+			want: `function _strigo_hook --on-variable PWD
+    '/opt/my tools/it\'s/strigo' hook-env --shell fish | source
+end
+_strigo_hook
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			got, err := hookScript(tt.shell, binary)
+			if err != nil {
+				t.Fatalf("hookScript: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := hookScript("tcsh", binary); err == nil {
+		t.Error("tcsh: want an unsupported shell error")
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strigo/installation"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var infoCmd = &cobra.Command{
+	Use:   "info [type] [distribution] [version]",
+	Short: "Show where an installed SDK version came from",
+	Long: `Show the install receipt of an SDK version: source registry and repository,
+download URL, checksum, archive size, installation date, SDK home and platform. For example:
+strigo info jdk temurin 21.0.6_7`,
+	Args: cobra.ExactArgs(3),
+	Run:  info,
+	Example: `  # Show the receipt of Temurin JDK 21
+  strigo info jdk temurin 21.0.6_7
+
+  # Same, as JSON
+  strigo info jdk temurin 21.0.6_7 --json`,
+}
+
+func info(cmd *cobra.Command, args []string) {
+	if err := handleInfo(args[0], args[1], args[2]); err != nil {
+		ExitWithError(err)
+	}
+}
+
+func handleInfo(sdkType, distribution, version string) error {
+	installPath, err := GetInstallPath(cfg, sdkType, distribution, version)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(installPath); os.IsNotExist(err) {
+		return fmt.Errorf("version %s %s %s is not installed", sdkType, distribution, version)
+	}
+
+	receipt, err := installation.ReadReceipt(installPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no receipt found for %s %s %s: it was installed before receipts were recorded", sdkType, distribution, version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return OutputJSON(receipt)
+	}
+
+	fmt.Printf("\n%s %s %s\n", receipt.SDKType, receipt.Distribution, receipt.Version)
+	fmt.Println("─────────────────────────────────")
+	fmt.Printf("  Installation path: %s\n", installPath)
+	fmt.Printf("  SDK home:          %s\n", filepath.Join(installPath, filepath.FromSlash(receipt.Home)))
+	fmt.Printf("  Platform:          %s/%s\n", receipt.OS, receipt.Arch)
+	fmt.Printf("  Registry:          %s\n", receipt.Registry)
+	fmt.Printf("  Repository:        %s\n", receipt.Repository)
+	fmt.Printf("  Download URL:      %s\n", receipt.DownloadURL)
+	fmt.Printf("  SHA-256:           %s\n", receipt.SHA256)
+	fmt.Printf("  Archive size:      %d bytes\n", receipt.ArchiveSize)
+	fmt.Printf("  Installed at:      %s\n", receipt.InstalledAt.Local().Format(time.RFC1123))
+	fmt.Printf("  Strigo version:    %s\n", receipt.StrigoVersion)
+	fmt.Println()
+
+	return nil
+}
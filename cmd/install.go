@@ -1,17 +1,38 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"strigo/config"
 	"strigo/downloader"
 	"strigo/downloader/core"
+	"strigo/installation"
 	"strigo/logging"
+	"strigo/project"
 	"strigo/repository"
+	sdkversion "strigo/version"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	installOS   string
+	installArch string
+)
+
+func init() {
+	installCmd.Flags().StringVar(&installOS, "os", runtime.GOOS, "Operating system of the SDK to install (e.g. linux, darwin)")
+	installCmd.Flags().StringVar(&installArch, "arch", runtime.GOARCH, "Architecture of the SDK to install (e.g. amd64, arm64)")
+}
+
 var installCmd = &cobra.Command{
 	Use:   "install [type] [distribution] [version]",
 	Short: "Install a specific SDK version",
@@ -19,6 +40,10 @@ var installCmd = &cobra.Command{
 	strigo install jdk temurin 11.0.24_8
 	strigo install jdk corretto 8u442b06
 
+Without arguments, install the versions pinned by the project files
+(.strigo.toml, .tool-versions, .sdkmanrc, .java-version, .nvmrc, .node-version)
+of the current directory and its parents.
+
 Available SDK types:
 	jdk     Java Development Kit
 
@@ -26,10 +51,11 @@ Available distributions for jdk:
 	temurin    Eclipse Temurin (AdoptOpenJDK)
 	corretto   Amazon Corretto`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 3 {
+		if len(args) != 0 && len(args) != 3 {
 			return fmt.Errorf("\n❌ Invalid number of arguments\n\n" +
 				"Usage:\n" +
-				"  strigo install [type] [distribution] [version]\n\n" +
+				"  strigo install [type] [distribution] [version]\n" +
+				"  strigo install (versions pinned by the project)\n\n" +
 				"Example:\n" +
 				"  strigo install jdk temurin 11.0.24_8\n\n" +
 				"To see available versions:\n" +
@@ -44,11 +70,24 @@ Available distributions for jdk:
   # Install Corretto JDK 8
   strigo install jdk corretto 8u442b06
 
+  # Install the versions pinned by the project
+  strigo install
+
+  # Install the ARM64 build to prepare another machine
+  strigo install jdk temurin 21.0.6_7 --arch arm64
+
   # To see available versions:
   strigo available jdk temurin`,
 }
 
 func install(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		if err := handleInstallPinned(); err != nil {
+			logging.LogError("❌ Error executing command: %v", err)
+		}
+		return
+	}
+
 	sdkType := args[0]
 	distribution := args[1]
 	version := args[2]
@@ -89,29 +128,38 @@ func handleInstall(sdkType, distribution, version string) error {
 		return nil
 	}
 
-	// Fetch available versions with filter
-	assets, err := repository.FetchAvailableVersions(sdkRepo, registry, version, true) // true to remove display
+	// Fetch every available version: the requested one may be an alias or a range
+	assets, err := repository.FetchAvailableVersions(sdkRepo, registry, "", true) // true to remove display
 	if err != nil {
 		logging.LogError("❌ Failed to fetch versions: %v", err)
 		return nil
 	}
 
-	// Find exact version match
-	var matchedAsset *repository.SDKAsset
-	for _, asset := range assets {
-		if asset.Version == version {
-			matchedAsset = &asset
-			break
+	// Resolve aliases (latest, lts, 21, >=17 <22) among the versions built for the target platform
+	requested := version
+	version, err = sdkversion.Resolve(requested, repository.PlatformVersions(assets, installOS, installArch), sdkTypeConfig.LTSMajors())
+	if err != nil {
+		// An exact version published for other platforms only gets a more helpful error
+		if _, platformErr := repository.SelectPlatformAsset(assets, requested, installOS, installArch); platformErr != nil {
+			logging.LogError("❌ %v", platformErr)
+			return nil
 		}
+		logging.LogError("❌ Version %s not found: %v", requested, err)
+		logging.LogInfo("💡 Use 'strigo available %s %s' to see available versions", sdkType, distribution)
+		return nil
+	}
+	if version != requested {
+		logging.LogInfo("🎯 Resolved %s to version %s", requested, version)
 	}
 
-	if matchedAsset == nil {
-		logging.LogError("❌ Version %s not found", version)
-		logging.LogInfo("💡 Use 'strigo available %s %s' to see available versions", sdkType, distribution)
+	// Find the asset of the resolved version for the target platform
+	matchedAsset, err := repository.SelectPlatformAsset(assets, version, installOS, installArch)
+	if err != nil {
+		logging.LogError("❌ %v", err)
 		return nil
 	}
 
-	logging.LogInfo("✅ Found version %s, preparing for installation...", version)
+	logging.LogInfo("✅ Found version %s (%s), preparing for installation...", version, matchedAsset.Platform())
 
 	// Get installation path
 	installPath, err := GetInstallPath(cfg, sdkType, distribution, version)
@@ -132,6 +180,93 @@ func handleInstall(sdkType, distribution, version string) error {
 		return nil
 	}
 
+	if err := installAsset(sdkType, distribution, version, sdkRepo, registry, matchedAsset, installPath); err != nil {
+		logging.LogError("❌ Installation failed: %v", err)
+		if errors.Is(err, context.Canceled) {
+			os.Exit(130)
+		}
+		return nil
+	}
+
+	refreshShims()
+
+	logging.LogInfo("✅ Successfully installed %s %s version %s", sdkType, distribution, version)
+	logging.LogInfo("📂 Installation path: %s", installPath)
+	logging.LogInfo("ℹ️  To set this version as active, run: strigo use %s %s %s", sdkType, distribution, version)
+
+	return nil
+}
+
+// handleInstallPinned installs the versions pinned for the current directory,
+// skipping the pins an installed version already satisfies
+func handleInstallPinned() error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	pins, err := project.Find(dir)
+	if err != nil {
+		return err
+	}
+	if len(pins) == 0 {
+		return fmt.Errorf("no version file found in %s or its parents (%s)", dir, strings.Join(project.FileNames(), ", "))
+	}
+
+	for _, pin := range pins {
+		active, err := resolvePin(pin)
+		if err != nil {
+			logging.LogError("❌ %v", err)
+			continue
+		}
+		if active.Installed {
+			logging.LogInfo("✅ %s %s %s is already installed (pinned by %s)", active.SDKType, active.Distribution, active.Version, pin.Source)
+			continue
+		}
+
+		logging.LogInfo("📌 Installing %s %s %s pinned by %s", active.SDKType, active.Distribution, pin.Version, pin.Source)
+		if err := handleInstall(active.SDKType, active.Distribution, pin.Version); err != nil {
+			logging.LogError("❌ %v", err)
+		}
+	}
+	return nil
+}
+
+// installAsset downloads and extracts an asset into a staging directory next to
+// installPath, runs the post-install steps there and only then renames it into place,
+// replacing an existing installation (see strigo repair).
+// The staging directory is removed on any error or interruption (Ctrl-C, SIGTERM).
+func installAsset(sdkType, distribution, version string, sdkRepo config.SDKRepository, registry config.Registry, asset *repository.SDKAsset, installPath string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Resolve registry credentials for the download
+	credentials, err := config.ResolveCredentials(sdkRepo.Registry, registry)
+	if err != nil {
+		return fmt.Errorf("failed to resolve credentials: %w", err)
+	}
+
+	// Create the staging directory next to the final location so the rename stays on the same filesystem
+	stagingPath, err := os.MkdirTemp(filepath.Dir(installPath), "."+filepath.Base(installPath)+".staging-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	if err := os.Chmod(stagingPath, 0755); err != nil {
+		os.RemoveAll(stagingPath)
+		return fmt.Errorf("failed to set staging directory permissions: %w", err)
+	}
+	logging.LogDebug("🚧 Staging installation in %s", stagingPath)
+
+	committed := false
+	defer func() {
+		if !committed {
+			logging.LogDebug("🧹 Removing staging directory: %s", stagingPath)
+			if err := os.RemoveAll(stagingPath); err != nil {
+				logging.LogError("❌ Failed to remove staging directory %s: %v", stagingPath, err)
+			}
+		}
+	}()
+
 	// Prepare certificate configuration
 	certConfig := core.CertConfig{
 		JDKSecurityPath:   cfg.General.JDKSecurityPath,
@@ -141,81 +276,157 @@ func handleInstall(sdkType, distribution, version string) error {
 	// Download and extract
 	manager := downloader.NewManager()
 	opts := core.DownloadOptions{
-		DownloadURL:  matchedAsset.DownloadUrl,
+		DownloadURL:  asset.DownloadUrl,
 		CacheDir:     cfg.General.CacheDir,
-		InstallPath:  installPath,
+		InstallPath:  stagingPath,
 		SDKType:      sdkType,
 		Distribution: distribution,
 		Version:      version,
 		KeepCache:    cfg.General.KeepCache,
 		CertConfig:   certConfig,
+		Checksums: core.Checksums{
+			SHA256: asset.SHA256,
+			SHA1:   asset.SHA1,
+		},
+		Credentials:  credentials,
+		Retries:      cfg.General.DownloadRetries,
+		RetryBackoff: cfg.General.RetryBackoffDuration(),
+		ExtractLimits: core.ExtractLimits{
+			MaxBytes: cfg.General.MaxExtractBytes,
+			MaxFiles: cfg.General.MaxExtractFiles,
+		},
+		StripComponents: sdkRepo.StripComponents,
+	}
+	result, err := manager.DownloadAndExtract(ctx, opts)
+	if err != nil {
+		return err
 	}
-	err = manager.DownloadAndExtract(opts)
 
+	// Detect and record the SDK home: the layout must be valid before going live
+	sdkPath, err := installation.DetectHome(stagingPath, sdkType)
 	if err != nil {
-		logging.LogError("❌ Installation failed: %v", err)
-		// Cleanup on failure
-		os.RemoveAll(installPath)
-		return nil
+		return fmt.Errorf("invalid SDK layout: %w", err)
+	}
+	if err := installation.RecordHome(stagingPath, sdkPath); err != nil {
+		return err
+	}
+	if err := writeReceipt(stagingPath, sdkPath, sdkType, distribution, version, sdkRepo, asset, result); err != nil {
+		return err
 	}
 
 	// For JDKs, manage certificates
 	if sdkType == "jdk" {
-		// Find the extracted JDK folder
-		entries, err := os.ReadDir(installPath)
-		if err != nil {
-			return fmt.Errorf("failed to read installation directory: %w", err)
+		if err := linkSystemCertificates(sdkPath); err != nil {
+			return err
 		}
+	}
 
-		// JDK directory selection logic
-		var jdkDir string
-		dirCount := 0
-		
-		// Count directories and remember the first one
-		for _, entry := range entries {
-			if entry.IsDir() {
-				dirCount++
-				// If it's the first directory, remember it
-				if jdkDir == "" {
-					jdkDir = entry.Name()
-				}
-			}
-		}
-		
-		// If multiple directories exist, it's ambiguous
-		if dirCount > 1 {
-			jdkDir = ""
-		}
+	// Capture the per-file manifest once the installation is final, for strigo verify
+	manifest, err := installation.BuildManifest(stagingPath)
+	if err != nil {
+		return err
+	}
+	if err := installation.WriteManifest(stagingPath, manifest); err != nil {
+		return err
+	}
 
-		if jdkDir == "" {
-			return fmt.Errorf("could not find JDK directory in %s", installPath)
-		}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
-		// Use the full path for certificates
-		jdkPath := filepath.Join(installPath, jdkDir)
-		jdkSecPath := filepath.Join(jdkPath, cfg.General.JDKSecurityPath)
+	// Move the staged installation into place
+	if err := swapInstallation(stagingPath, installPath); err != nil {
+		return err
+	}
+	committed = true
 
-		// 1. Remove default JDK certificates
-		logging.LogDebug("🗑️ Removing default JDK certificates...")
-		if err := os.RemoveAll(jdkSecPath); err != nil && !os.IsNotExist(err) {
-			return fmt.Errorf("failed to remove default certificates: %w", err)
-		}
+	return nil
+}
 
-		// 2. Create a symbolic link to system certificates
-		logging.LogDebug("🔗 Creating link to system certificates...")
-		if err := os.MkdirAll(filepath.Dir(jdkSecPath), 0755); err != nil {
-			return fmt.Errorf("failed to create security directory: %w", err)
+// swapInstallation renames the staging directory to installPath. An existing
+// installation is set aside first and restored if the rename fails, so the
+// path seen by current-<type> links and shell exports never stays empty.
+func swapInstallation(stagingPath, installPath string) error {
+	if _, err := os.Lstat(installPath); os.IsNotExist(err) {
+		if err := os.Rename(stagingPath, installPath); err != nil {
+			return fmt.Errorf("failed to move installation into place: %w", err)
 		}
+		return nil
+	}
 
-		if err := os.Symlink(cfg.General.SystemCacertsPath, jdkSecPath); err != nil {
-			return fmt.Errorf("failed to create symlink to system certificates: %w", err)
+	previousPath := filepath.Join(filepath.Dir(installPath), "."+filepath.Base(installPath)+".previous")
+	if err := os.RemoveAll(previousPath); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", previousPath, err)
+	}
+	if err := os.Rename(installPath, previousPath); err != nil {
+		return fmt.Errorf("failed to move previous installation aside: %w", err)
+	}
+
+	if err := os.Rename(stagingPath, installPath); err != nil {
+		if restoreErr := os.Rename(previousPath, installPath); restoreErr != nil {
+			logging.LogError("❌ Failed to restore previous installation from %s: %v", previousPath, restoreErr)
 		}
-		logging.LogInfo("✅ Successfully linked system certificates")
+		return fmt.Errorf("failed to move installation into place: %w", err)
 	}
 
-	logging.LogInfo("✅ Successfully installed %s %s version %s", sdkType, distribution, version)
-	logging.LogInfo("📂 Installation path: %s", installPath)
-	logging.LogInfo("ℹ️  To set this version as active, run: strigo use %s %s %s", sdkType, distribution, version)
+	if err := os.RemoveAll(previousPath); err != nil {
+		logging.LogError("❌ Failed to remove previous installation %s: %v", previousPath, err)
+	}
+	return nil
+}
 
+// writeReceipt records where an installation came from
+func writeReceipt(installPath, sdkPath, sdkType, distribution, version string, sdkRepo config.SDKRepository, asset *repository.SDKAsset, result *core.DownloadResult) error {
+	home, err := filepath.Rel(installPath, sdkPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute SDK home: %w", err)
+	}
+
+	// Assets without platform in their name are recorded for the platform they were installed for
+	targetOS, targetArch := asset.OS, asset.Arch
+	if targetOS == "" {
+		targetOS = installOS
+	}
+	if targetArch == "" {
+		targetArch = installArch
+	}
+
+	return installation.WriteReceipt(installPath, installation.Receipt{
+		SDKType:       sdkType,
+		Distribution:  distribution,
+		Version:       version,
+		Registry:      sdkRepo.Registry,
+		Repository:    sdkRepo.Repository,
+		DownloadURL:   asset.DownloadUrl,
+		SHA256:        result.SHA256,
+		ArchiveSize:   result.Size,
+		InstalledAt:   time.Now().UTC(),
+		Home:          filepath.ToSlash(home),
+		OS:            targetOS,
+		Arch:          targetArch,
+		StrigoVersion: buildVersion,
+	})
+}
+
+// linkSystemCertificates replaces the JDK truststore with a link to the system certificates
+func linkSystemCertificates(jdkPath string) error {
+	jdkSecPath := filepath.Join(jdkPath, cfg.General.JDKSecurityPath)
+
+	// 1. Remove default JDK certificates
+	logging.LogDebug("🗑️ Removing default JDK certificates...")
+	if err := os.RemoveAll(jdkSecPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove default certificates: %w", err)
+	}
+
+	// 2. Create a symbolic link to system certificates
+	logging.LogDebug("🔗 Creating link to system certificates...")
+	if err := os.MkdirAll(filepath.Dir(jdkSecPath), 0755); err != nil {
+		return fmt.Errorf("failed to create security directory: %w", err)
+	}
+
+	if err := os.Symlink(cfg.General.SystemCacertsPath, jdkSecPath); err != nil {
+		return fmt.Errorf("failed to create symlink to system certificates: %w", err)
+	}
+	logging.LogInfo("✅ Successfully linked system certificates")
 	return nil
 }
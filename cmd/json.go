@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"strigo/installation"
 )
 
 // Variables globales pour le mode JSON
@@ -18,10 +19,11 @@ func GetJsonOutput() bool {
 
 // CommandOutput structure pour la sortie JSON
 type CommandOutput struct {
-	Types         []string `json:"types,omitempty"`
-	Distributions []string `json:"distributions,omitempty"`
-	Versions      []string `json:"versions,omitempty"`
-	Error         string   `json:"error,omitempty"`
+	Types         []string                         `json:"types,omitempty"`
+	Distributions []string                         `json:"distributions,omitempty"`
+	Versions      []string                         `json:"versions,omitempty"`
+	Receipts      map[string]*installation.Receipt `json:"receipts,omitempty"` // Install receipts, par version
+	Error         string                           `json:"error,omitempty"`
 }
 
 // OutputJSON gère la sortie JSON pour toutes les commandes
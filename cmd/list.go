@@ -5,9 +5,10 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strigo/config"
-	"strigo/repository"
+	"strigo/installation"
+	sdkversion "strigo/version"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -162,18 +163,28 @@ func listVersions(cfg *config.Config, sdkType, distribution string, output *Comm
 
 	var versions []string
 	for _, entry := range entries {
-		if entry.IsDir() {
+		// Hidden entries are staging directories of installations in progress
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
 			versions = append(versions, entry.Name())
 		}
 	}
 
 	// Trier les versions
-	sort.Slice(versions, func(i, j int) bool {
-		return repository.CompareVersions(versions[i], versions[j])
-	})
+	sdkversion.Sort(versions)
 	output.Versions = versions
 
 	if jsonOutput {
+		// Joindre les reçus d'installation, absents pour les installations antérieures
+		for _, version := range versions {
+			receipt, err := installation.ReadReceipt(filepath.Join(basePath, version))
+			if err != nil {
+				continue
+			}
+			if output.Receipts == nil {
+				output.Receipts = make(map[string]*installation.Receipt)
+			}
+			output.Receipts[version] = receipt
+		}
 		return OutputJSON(output)
 	}
 
@@ -182,36 +193,13 @@ func listVersions(cfg *config.Config, sdkType, distribution string, output *Comm
 		return nil
 	}
 
-	// Grouper les versions par version majeure
-	versionGroups := make(map[string][]string)
-	for _, version := range versions {
-		majorVersion := repository.ExtractMajorVersion(version)
-		versionGroups[majorVersion] = append(versionGroups[majorVersion], version)
-	}
-
-	// Obtenir les versions majeures triées
-	var majorVersions []int
-	for major := range versionGroups {
-		if num, err := strconv.Atoi(major); err == nil {
-			majorVersions = append(majorVersions, num)
-		}
-	}
-	sort.Ints(majorVersions)
-
 	fmt.Printf("\nInstalled %s %s versions:\n", sdkType, distribution)
 	fmt.Println("─────────────────────────────────")
 
-	for _, majorNum := range majorVersions {
-		major := strconv.Itoa(majorNum)
-		versions := versionGroups[major]
-
-		// Trier les versions dans chaque groupe
-		sort.Slice(versions, func(i, j int) bool {
-			return repository.CompareVersions(versions[i], versions[j])
-		})
-
-		fmt.Printf("-%s :\n", major)
-		for _, version := range versions {
+	// Afficher les versions groupées par version majeure
+	for _, group := range sdkversion.GroupByMajor(versions) {
+		fmt.Printf("-%s :\n", group.Major)
+		for _, version := range group.Versions {
 			fmt.Printf("    ✅ %s\n", version)
 		}
 		fmt.Println()
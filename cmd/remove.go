@@ -38,6 +38,7 @@ func remove(cmd *cobra.Command, args []string) {
 		logging.LogError("Failed to remove version: %v", err)
 		return
 	}
+	refreshShims()
 
 	logging.LogInfo("✅ Successfully removed %s %s version %s", tool, vendor, version)
 }
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strigo/config"
+	"strigo/installation"
+	"strigo/logging"
+	"strigo/repository"
+
+	"github.com/spf13/cobra"
+)
+
+var repairForce bool
+
+func init() {
+	repairCmd.Flags().BoolVar(&repairForce, "force", false, "Reinstall even if no problem is detected")
+}
+
+var repairCmd = &cobra.Command{
+	Use:   "repair [type] [distribution] [version]",
+	Short: "Reinstall a damaged SDK version in place",
+	Long: `Detect a damaged installation (missing bin/ executables, broken certificate link,
+absent release file, files differing from the install manifest) and restore it by
+reinstalling the same archive at the same path. For example:
+strigo repair jdk temurin 21.0.6_7
+
+The archive recorded in the install receipt is used, from the cache when it is still there.
+The installation path does not change, so current-<type> links and shell exports keep working.`,
+	Args: cobra.ExactArgs(3),
+	Run:  repair,
+	Example: `  # Repair Temurin JDK 21
+  strigo repair jdk temurin 21.0.6_7
+
+  # Reinstall even if the installation looks healthy
+  strigo repair jdk temurin 21.0.6_7 --force`,
+}
+
+func repair(cmd *cobra.Command, args []string) {
+	if err := handleRepair(args[0], args[1], args[2]); err != nil {
+		if errors.Is(err, context.Canceled) {
+			logging.LogError("❌ Repair interrupted")
+			os.Exit(130)
+		}
+		ExitWithError(err)
+	}
+}
+
+func handleRepair(sdkType, distribution, version string) error {
+	installPath, err := GetInstallPath(cfg, sdkType, distribution, version)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(installPath); os.IsNotExist(err) {
+		return fmt.Errorf("version %s %s %s is not installed", sdkType, distribution, version)
+	}
+
+	problems, err := installation.Diagnose(installPath, sdkType, cfg.General.JDKSecurityPath)
+	if err != nil {
+		return fmt.Errorf("failed to check installation: %w", err)
+	}
+	if len(problems) == 0 && !repairForce {
+		logging.LogInfo("✅ %s %s %s is healthy, nothing to repair", sdkType, distribution, version)
+		return nil
+	}
+	for _, problem := range problems {
+		logging.LogInfo("⚠️  %s", problem)
+	}
+
+	sdkRepo, registry, asset, err := repairSource(sdkType, distribution, version, installPath)
+	if err != nil {
+		return err
+	}
+
+	logging.LogInfo("🔧 Reinstalling %s %s %s from %s", sdkType, distribution, version, asset.DownloadUrl)
+	if err := installAsset(sdkType, distribution, version, sdkRepo, registry, asset, installPath); err != nil {
+		return fmt.Errorf("repair failed: %w", err)
+	}
+
+	// Problems outside the archive (e.g. missing system certificates) survive a reinstall
+	remaining, err := installation.Diagnose(installPath, sdkType, cfg.General.JDKSecurityPath)
+	if err != nil {
+		return fmt.Errorf("failed to check repaired installation: %w", err)
+	}
+	if len(remaining) > 0 {
+		for _, problem := range remaining {
+			logging.LogError("❌ %s", problem)
+		}
+		return fmt.Errorf("%s %s %s was reinstalled but %d problem(s) remain, check system_cacerts_path", sdkType, distribution, version, len(remaining))
+	}
+
+	logging.LogInfo("✅ Successfully repaired %s %s version %s", sdkType, distribution, version)
+	return nil
+}
+
+// repairSource returns the asset an installation was made from: the one recorded
+// in its receipt or, for installations without receipt, the matching repository asset
+func repairSource(sdkType, distribution, version, installPath string) (config.SDKRepository, config.Registry, *repository.SDKAsset, error) {
+	sdkRepo, exists := cfg.SDKRepositories[distribution]
+	if !exists {
+		return config.SDKRepository{}, config.Registry{}, nil, fmt.Errorf("distribution %s not found in configuration", distribution)
+	}
+
+	receipt, err := installation.ReadReceipt(installPath)
+	switch {
+	case err == nil:
+		registry, exists := cfg.Registries[receipt.Registry]
+		if !exists {
+			return sdkRepo, config.Registry{}, nil, fmt.Errorf("registry %s not found in configuration", receipt.Registry)
+		}
+		sdkRepo.Registry = receipt.Registry
+		sdkRepo.Repository = receipt.Repository
+		return sdkRepo, registry, &repository.SDKAsset{
+			Version:     receipt.Version,
+			DownloadUrl: receipt.DownloadURL,
+			SHA256:      receipt.SHA256,
+			OS:          receipt.OS,
+			Arch:        receipt.Arch,
+		}, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return sdkRepo, config.Registry{}, nil, err
+	}
+
+	logging.LogDebug("🔍 No receipt for %s %s %s, looking the version up in the repository", sdkType, distribution, version)
+	registry, exists := cfg.Registries[sdkRepo.Registry]
+	if !exists {
+		return sdkRepo, config.Registry{}, nil, fmt.Errorf("registry %s not found in configuration", sdkRepo.Registry)
+	}
+
+	assets, err := repository.FetchAvailableVersions(sdkRepo, registry, version, true)
+	if err != nil {
+		return sdkRepo, registry, nil, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+	asset, err := repository.SelectPlatformAsset(assets, version, installOS, installArch)
+	if err != nil {
+		return sdkRepo, registry, nil, err
+	}
+	if asset == nil {
+		return sdkRepo, registry, nil, fmt.Errorf("version %s is no longer available in %s", version, distribution)
+	}
+	return sdkRepo, registry, asset, nil
+}
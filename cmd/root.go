@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strigo/config"
 	"strigo/logging"
 
@@ -11,6 +12,10 @@ import (
 // Global config variable
 var cfg *config.Config
 
+// annotationMachineOutput marks the commands whose standard output is read by a
+// shell or another program: their logs are printed on standard error
+const annotationMachineOutput = "strigo/machine-output"
+
 // Root command
 var rootCmd = &cobra.Command{
 	Use:           "strigo",
@@ -31,6 +36,11 @@ var rootCmd = &cobra.Command{
 			return fmt.Errorf("error ensuring directories: %w", err)
 		}
 
+		// Keep standard output clean for commands evaluated by a shell
+		if cmd.Annotations[annotationMachineOutput] != "" {
+			logging.SetConsole(os.Stderr)
+		}
+
 		// Initialize logger with JSON format if requested
 		if err := logging.InitLogger(cfg.General.LogPath, cfg.General.LogLevel, jsonOutput || jsonLogs); err != nil {
 			return fmt.Errorf("failed to initialize logger: %w", err)
@@ -51,6 +61,17 @@ func init() {
 	rootCmd.AddCommand(useCmd)
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(repairCmd)
+	rootCmd.AddCommand(currentCmd)
+	rootCmd.AddCommand(hookCmd)
+	rootCmd.AddCommand(hookEnvCmd)
+	rootCmd.AddCommand(reshimCmd)
+	rootCmd.AddCommand(whichCmd)
+	rootCmd.AddCommand(shimExecCmd)
+	rootCmd.AddCommand(execCmd)
+	rootCmd.AddCommand(envCmd)
 
 	// Allow flags to be placed after arguments
 	rootCmd.Flags().SetInterspersed(true)
@@ -60,6 +81,15 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonLogs, "json-logs", false, "Output logs in JSON format")
 }
 
+// Version of the running binary, recorded in install receipts
+var buildVersion = "dev"
+
+// SetVersionInfo records the build information injected at link time
+func SetVersionInfo(version, commit, date string) {
+	buildVersion = version
+	rootCmd.Version = fmt.Sprintf("%s (commit %s, built %s)", version, commit, date)
+}
+
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// homeVariables maps SDK types to the variable pointing at their home
+var homeVariables = map[string]string{
+	"jdk":  "JAVA_HOME",
+	"node": "NODE_HOME",
+}
+
+// homeVariable returns the home variable of a configured SDK type, or "" if it has none
+func homeVariable(sdkType string) string {
+	if sdkTypeConfig, exists := cfg.SDKTypes[sdkType]; exists {
+		return homeVariables[sdkTypeConfig.Type]
+	}
+	return homeVariables[sdkType]
+}
+
+// sdkVariables returns the variables selecting an SDK installed at home: its home
+// variable, then the variables configured for its type, sorted by name
+func sdkVariables(sdkType, home string) []envChange {
+	var changes []envChange
+	if homeVar := homeVariable(sdkType); homeVar != "" {
+		changes = append(changes, envChange{Name: homeVar, Value: home})
+	}
+
+	env := cfg.SDKTypes[sdkType].Environment(home)
+	var names []string
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		changes = append(changes, envChange{Name: name, Value: env[name]})
+	}
+	return changes
+}
+
+// envChange is a variable to set, or to unset when Unset is true
+type envChange struct {
+	Name  string
+	Value string
+	Unset bool
+}
+
+// shellSyntax renders environment changes for one shell or file format,
+// line by line or, when document is set, as a whole
+type shellSyntax struct {
+	set      func(name, value string) string
+	unset    func(name string) string
+	document func(changes []envChange) string
+}
+
+// shellSyntaxes lists the shells and formats environment changes can be rendered for
+var shellSyntaxes = map[string]shellSyntax{
+	"bash":           {set: posixSet, unset: posixUnset},
+	"zsh":            {set: posixSet, unset: posixUnset},
+	"fish":           {set: fishSet, unset: fishUnset},
+	"nu":             {set: nuSet, unset: nuUnset},
+	"dotenv":         {set: dotenvSet, unset: dotenvUnset},
+	"github-actions": {set: githubEnvSet, unset: dotenvUnset},
+	"json":           {document: jsonDocument},
+}
+
+// lookupShell returns the syntax of a shell
+func lookupShell(shell string) (shellSyntax, error) {
+	syntax, supported := shellSyntaxes[shell]
+	if !supported {
+		return shellSyntax{}, fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(supportedShells(), ", "))
+	}
+	return syntax, nil
+}
+
+// supportedShells returns the names of the supported shells
+func supportedShells() []string {
+	var shells []string
+	for shell := range shellSyntaxes {
+		shells = append(shells, shell)
+	}
+	sort.Strings(shells)
+	return shells
+}
+
+// render returns the commands applying environment changes, one per line
+func (s shellSyntax) render(changes []envChange) string {
+	if s.document != nil {
+		return s.document(changes)
+	}
+
+	var b strings.Builder
+	for _, change := range changes {
+		if change.Unset {
+			b.WriteString(s.unset(change.Name))
+		} else {
+			b.WriteString(s.set(change.Name, change.Value))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func posixSet(name, value string) string {
+	return fmt.Sprintf("export %s=%s", name, posixQuote(value))
+}
+
+func posixUnset(name string) string {
+	return fmt.Sprintf("unset %s", name)
+}
+
+func fishSet(name, value string) string {
+	// PATH is a list in fish
+	if name == "PATH" {
+		var quoted []string
+		for _, dir := range filepath.SplitList(value) {
+			quoted = append(quoted, fishQuote(dir))
+		}
+		return fmt.Sprintf("set -gx PATH %s", strings.Join(quoted, " "))
+	}
+	return fmt.Sprintf("set -gx %s %s", name, fishQuote(value))
+}
+
+func fishUnset(name string) string {
+	return fmt.Sprintf("set -e %s", name)
+}
+
+func nuSet(name, value string) string {
+	// PATH is a list in nushell
+	if name == "PATH" {
+		var quoted []string
+		for _, dir := range filepath.SplitList(value) {
+			quoted = append(quoted, doubleQuote(dir))
+		}
+		return fmt.Sprintf("$env.PATH = [%s]", strings.Join(quoted, ", "))
+	}
+	return fmt.Sprintf("$env.%s = %s", name, doubleQuote(value))
+}
+
+func nuUnset(name string) string {
+	return fmt.Sprintf("hide-env --ignore-errors %s", name)
+}
+
+func dotenvSet(name, value string) string {
+	return fmt.Sprintf("%s=%s", name, doubleQuote(value))
+}
+
+// dotenvUnset empties a variable, dotenv files and $GITHUB_ENV having no way to unset one
+func dotenvUnset(name string) string {
+	return fmt.Sprintf("%s=", name)
+}
+
+// githubEnvSet writes a line of $GITHUB_ENV, whose values are taken literally
+func githubEnvSet(name, value string) string {
+	if strings.Contains(value, "\n") {
+		return fmt.Sprintf("%s<<STRIGO_EOF\n%s\nSTRIGO_EOF", name, value)
+	}
+	return fmt.Sprintf("%s=%s", name, value)
+}
+
+// jsonDocument renders the changes as a JSON object, unset variables being null
+func jsonDocument(changes []envChange) string {
+	env := make(map[string]*string, len(changes))
+	for _, change := range changes {
+		value := change.Value
+		if change.Unset {
+			env[change.Name] = nil
+		} else {
+			env[change.Name] = &value
+		}
+	}
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return "{}\n"
+	}
+	return string(data) + "\n"
+}
+
+// doubleQuote quotes a value with JSON escapes, understood by nushell and dotenv parsers
+func doubleQuote(value string) string {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return `""`
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// posixQuote quotes a value for sh, bash and zsh
+func posixQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes a value for fish
+func fishQuote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// executablePath returns the path of the running strigo binary, for scripts calling it back
+func executablePath() string {
+	path, err := os.Executable()
+	if err != nil {
+		return "strigo"
+	}
+	return path
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import "testing"
+
+func TestShellRender(t *testing.T) {
+	changes := []envChange{
+		{Name: "JAVA_HOME", Value: "/home/me/My SDKs/jdk's home"},
+		{Name: "NODE_HOME", Value: `/opt/node\current`},
+		{Name: "_STRIGO_PREV_JAVA_HOME", Unset: true},
+		{Name: "PATH", Value: "/home/me/My SDKs/jdk's home/bin:/usr/bin"},
+	}
+
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{
+			shell: "bash",
+			want: `export JAVA_HOME='/home/me/My SDKs/jdk'\''s home'
+export NODE_HOME='/opt/node\current'
+unset _STRIGO_PREV_JAVA_HOME
+export PATH='/home/me/My SDKs/jdk'\''s home/bin:/usr/bin'
+`,
+		},
+		{
+			shell: "zsh",
+			want: `export JAVA_HOME='/home/me/My SDKs/jdk'\''s home'
+export NODE_HOME='/opt/node\current'
+unset _STRIGO_PREV_JAVA_HOME
+export PATH='/home/me/My SDKs/jdk'\''s home/bin:/usr/bin'
+`,
+		},
+		{
+			shell: "fish",
+			want: `set -gx JAVA_HOME '/home/me/My SDKs/jdk\'s home'
+set -gx NODE_HOME '/opt/node\\current'
+set -e _STRIGO_PREV_JAVA_HOME
+set -gx PATH '/home/me/My SDKs/jdk\'s home/bin' '/usr/bin'
+`,
+		},
+		{
+			shell: "nu",
+			want: `$env.JAVA_HOME = "/home/me/My SDKs/jdk's home"
+$env.NODE_HOME = "/opt/node\\current"
+hide-env --ignore-errors _STRIGO_PREV_JAVA_HOME
+$env.PATH = ["/home/me/My SDKs/jdk's home/bin", "/usr/bin"]
+`,
+		},
+		{
+			shell: "dotenv",
+			want: `JAVA_HOME="/home/me/My SDKs/jdk's home"
+NODE_HOME="/opt/node\\current"
+_STRIGO_PREV_JAVA_HOME=
+PATH="/home/me/My SDKs/jdk's home/bin:/usr/bin"
+`,
+		},
+		{
+			shell: "github-actions",
+			want: `JAVA_HOME=/home/me/My SDKs/jdk's home
+NODE_HOME=/opt/node\current
+_STRIGO_PREV_JAVA_HOME=
+PATH=/home/me/My SDKs/jdk's home/bin:/usr/bin
+`,
+		},
+		{
+			shell: "json",
+			want: `{
+  "JAVA_HOME": "/home/me/My SDKs/jdk's home",
+  "NODE_HOME": "/opt/node\\current",
+  "PATH": "/home/me/My SDKs/jdk's home/bin:/usr/bin",
+  "_STRIGO_PREV_JAVA_HOME": null
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			syntax, err := lookupShell(tt.shell)
+			if err != nil {
+				t.Fatalf("lookupShell: %v", err)
+			}
+			if got := syntax.render(changes); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellRenderQuotesAndNewlines(t *testing.T) {
+	changes := []envChange{{Name: "NOTE", Value: "say \"hi\"\nbye"}}
+
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{"nu", `$env.NOTE = "say \"hi\"\nbye"` + "\n"},
+		{"dotenv", `NOTE="say \"hi\"\nbye"` + "\n"},
+		{"github-actions", "NOTE<<STRIGO_EOF\nsay \"hi\"\nbye\nSTRIGO_EOF\n"},
+		{"json", "{\n  \"NOTE\": \"say \\\"hi\\\"\\nbye\"\n}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			syntax, err := lookupShell(tt.shell)
+			if err != nil {
+				t.Fatalf("lookupShell: %v", err)
+			}
+			if got := syntax.render(changes); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
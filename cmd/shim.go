@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strigo/installation"
+	"strigo/logging"
+	"strigo/project"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// shimsDirName is the directory of the launcher shims, inside the SDK install directory
+const shimsDirName = "shims"
+
+// shimMarker identifies the files generated by reshim
+const shimMarker = "# strigo shim"
+
+// shimTemplate runs the tool of the global selection directly, and delegates to
+// strigo when a version override is set or nothing is selected
+const shimTemplate = `#!/bin/sh
+%[1]s for %[2]s (%[3]s), regenerate with: strigo reshim
+if [ -z "${%[4]s-}" ] && [ -x %[5]s ]; then
+  exec %[5]s "$@"
+fi
+exec %[6]s shim-exec %[3]s %[2]s -- "$@"
+`
+
+var reshimCmd = &cobra.Command{
+	Use:   "reshim",
+	Short: "Regenerate the launcher shims of installed SDK executables",
+	Long: `Regenerate the shims directory (<sdk_install_dir>/shims) with a launcher for
+every executable found in the bin/ directory of installed SDKs (java, javac, node,
+npm...). Each shim runs the version selected by STRIGO_<TYPE>_VERSION when set
+(e.g. STRIGO_JDK_VERSION=temurin:17), else the global version set with 'strigo use'.
+
+Once generated, shims are refreshed after each install and removal.`,
+	Args: cobra.NoArgs,
+	Run:  reshim,
+}
+
+var whichCmd = &cobra.Command{
+	Use:         "which [tool]",
+	Short:       "Show the executable a shim would run",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationMachineOutput: "true"},
+	Run:         which,
+	Example: `  # Show the java binary in use
+  strigo which java
+
+  # With an override
+  STRIGO_JDK_VERSION=temurin:17 strigo which java`,
+}
+
+var shimExecCmd = &cobra.Command{
+	Use:                "shim-exec [type] [tool] -- [args...]",
+	Short:              "Run the executable of a shim",
+	Hidden:             true,
+	DisableFlagParsing: true,
+	Annotations:        map[string]string{annotationMachineOutput: "true"},
+	Run:                shimExec,
+}
+
+// WhichOutput is the JSON output of strigo which
+type WhichOutput struct {
+	Tool         string `json:"tool"`
+	SDKType      string `json:"sdk_type"`
+	Distribution string `json:"distribution,omitempty"`
+	Version      string `json:"version,omitempty"`
+	Path         string `json:"path"`
+	Source       string `json:"source"` // Override variable, or "global"
+}
+
+func reshim(cmd *cobra.Command, args []string) {
+	count, err := writeShims()
+	if err != nil {
+		ExitWithError(err)
+	}
+
+	dir := shimsDir()
+	logging.LogInfo("✅ Generated %d shims in %s", count, dir)
+	if !pathContains(dir) {
+		logging.LogInfo("ℹ️  Add the shims directory to your PATH:")
+		logging.LogInfo("   export PATH=%s:$PATH", dir)
+	}
+}
+
+func which(cmd *cobra.Command, args []string) {
+	tool := args[0]
+
+	tools, err := collectShimTools()
+	if err != nil {
+		ExitWithError(err)
+	}
+	sdkType, exists := tools[tool]
+	if !exists {
+		ExitWithError(fmt.Errorf("%s is not provided by any installed SDK", tool))
+	}
+
+	path, active, err := shimTarget(sdkType, tool)
+	if err != nil {
+		ExitWithError(err)
+	}
+
+	if jsonOutput {
+		if err := OutputJSON(WhichOutput{
+			Tool:         tool,
+			SDKType:      sdkType,
+			Distribution: active.Distribution,
+			Version:      active.Version,
+			Path:         path,
+			Source:       active.Source,
+		}); err != nil {
+			ExitWithError(err)
+		}
+		return
+	}
+
+	logging.LogInfo("🔎 %s from %s %s %s (%s)", tool, sdkType, active.Distribution, active.Version, active.Source)
+	fmt.Println(path)
+}
+
+func shimExec(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		ExitWithError(fmt.Errorf("usage: strigo shim-exec [type] [tool] -- [args...]"))
+	}
+	sdkType, tool, toolArgs := args[0], args[1], args[2:]
+	if len(toolArgs) > 0 && toolArgs[0] == "--" {
+		toolArgs = toolArgs[1:]
+	}
+
+	path, _, err := shimTarget(sdkType, tool)
+	if err != nil {
+		ExitWithError(err)
+	}
+
+	if err := syscall.Exec(path, append([]string{path}, toolArgs...), os.Environ()); err != nil {
+		ExitWithError(fmt.Errorf("failed to run %s: %w", path, err))
+	}
+}
+
+// shimsDir returns the directory of the launcher shims
+func shimsDir() string {
+	return filepath.Join(cfg.General.SDKInstallDir, shimsDirName)
+}
+
+// versionOverrideVariable returns the variable selecting the version of an SDK type for shims
+func versionOverrideVariable(sdkType string) string {
+	return "STRIGO_" + strings.ToUpper(strings.ReplaceAll(sdkType, "-", "_")) + "_VERSION"
+}
+
+// shimTarget returns the executable a shim runs: the tool of the version set by
+// STRIGO_<TYPE>_VERSION ([distribution:]version), else of the global selection
+func shimTarget(sdkType, tool string) (string, *activeSDK, error) {
+	if _, exists := cfg.SDKTypes[sdkType]; !exists {
+		return "", nil, fmt.Errorf("SDK type %s not found in configuration", sdkType)
+	}
+
+	var active *activeSDK
+	variable := versionOverrideVariable(sdkType)
+	if override := os.Getenv(variable); override != "" {
+		pin := project.Pin{SDKType: sdkType, Version: override, Source: variable}
+		if distribution, version, found := strings.Cut(override, ":"); found {
+			pin.Distribution = distribution
+			pin.Version = version
+		}
+
+		var err error
+		active, err = resolvePin(pin)
+		if err != nil {
+			return "", nil, err
+		}
+		if !active.Installed {
+			return "", nil, fmt.Errorf("%s %s %s selected by %s is not installed", sdkType, active.Distribution, active.Requested, variable)
+		}
+	} else {
+		active = globalSDK(sdkType)
+		if active.Source == sourceNone {
+			return "", nil, fmt.Errorf("no %s version selected: run 'strigo use' or set %s", sdkType, variable)
+		}
+	}
+
+	path := filepath.Join(active.Home, "bin", tool)
+	if !isExecutable(path) {
+		return "", nil, fmt.Errorf("%s is not provided by %s %s %s", tool, sdkType, active.Distribution, active.Version)
+	}
+	return path, active, nil
+}
+
+// collectShimTools maps every executable of the installed SDKs to its SDK type.
+// A tool provided by several types goes to the first one in alphabetical order.
+func collectShimTools() (map[string]string, error) {
+	installed, err := findInstallations(cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make(map[string]string)
+	for _, sdk := range installed {
+		home, err := installation.Home(sdk.Path, sdk.SDKType)
+		if err != nil {
+			logging.LogDebug("⚠️ Skipping %s %s %s: %v", sdk.SDKType, sdk.Distribution, sdk.Version, err)
+			continue
+		}
+
+		binDir := filepath.Join(home, "bin")
+		entries, err := os.ReadDir(binDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !isExecutable(filepath.Join(binDir, entry.Name())) {
+				continue
+			}
+			if owner, exists := tools[entry.Name()]; exists && owner != sdk.SDKType {
+				logging.LogDebug("⚠️ %s is provided by both %s and %s, keeping %s", entry.Name(), owner, sdk.SDKType, owner)
+				continue
+			}
+			tools[entry.Name()] = sdk.SDKType
+		}
+	}
+	return tools, nil
+}
+
+// writeShims regenerates the shims directory, removing the shims of tools no
+// longer installed, and returns the number of shims
+func writeShims() (int, error) {
+	tools, err := collectShimTools()
+	if err != nil {
+		return 0, err
+	}
+
+	dir := shimsDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create shims directory: %w", err)
+	}
+
+	// Remove stale shims, leaving files not generated by strigo alone
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read shims directory: %w", err)
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if _, keep := tools[entry.Name()]; keep || !isShim(path) {
+			continue
+		}
+		logging.LogDebug("🗑️ Removing stale shim: %s", path)
+		if err := os.Remove(path); err != nil {
+			return 0, fmt.Errorf("failed to remove stale shim %s: %w", path, err)
+		}
+	}
+
+	var names []string
+	for tool := range tools {
+		names = append(names, tool)
+	}
+	sort.Strings(names)
+
+	binary := posixQuote(executablePath())
+	for _, tool := range names {
+		sdkType := tools[tool]
+		current := filepath.Join(cfg.General.SDKInstallDir, fmt.Sprintf("current-%s", sdkType), "bin", tool)
+		script := fmt.Sprintf(shimTemplate, shimMarker, tool, sdkType, versionOverrideVariable(sdkType), posixQuote(current), binary)
+
+		// Write then rename, so a running shell never sees a partial shim
+		path := filepath.Join(dir, tool)
+		tmpPath := path + ".tmp"
+		if err := os.WriteFile(tmpPath, []byte(script), 0755); err != nil {
+			return 0, fmt.Errorf("failed to write shim %s: %w", path, err)
+		}
+		if err := os.Rename(tmpPath, path); err != nil {
+			os.Remove(tmpPath)
+			return 0, fmt.Errorf("failed to write shim %s: %w", path, err)
+		}
+	}
+	return len(names), nil
+}
+
+// refreshShims regenerates the shims after an install or a removal, once reshim has created them
+func refreshShims() {
+	if _, err := os.Stat(shimsDir()); err != nil {
+		return
+	}
+	if _, err := writeShims(); err != nil {
+		logging.LogError("⚠️ Failed to refresh shims: %v", err)
+		return
+	}
+	logging.LogDebug("🔗 Shims refreshed in %s", shimsDir())
+}
+
+// isShim reports whether a file was generated by reshim
+func isShim(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), shimMarker)
+}
+
+// isExecutable reports whether path, following links, is an executable file
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
+
+// pathContains reports whether dir is listed in PATH
+func pathContains(dir string) bool {
+	for _, entry := range filepath.SplitList(os.Getenv("PATH")) {
+		if entry == dir {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strigo/config"
+	"strings"
+	"testing"
+)
+
+// setupShimSDKs points the configuration at a temporary SDK install directory holding
+// temurin 17.0.9 and 21.0.6, the latter selected globally, and returns the JDK homes
+func setupShimSDKs(t *testing.T) (installDir string, homes map[string]string) {
+	t.Helper()
+	installDir = t.TempDir()
+
+	previous := cfg
+	t.Cleanup(func() { cfg = previous })
+	cfg = &config.Config{
+		General:         config.GeneralConfig{SDKInstallDir: installDir},
+		SDKTypes:        map[string]config.SDKType{"jdk": {Type: "jdk", InstallDir: "jdks"}},
+		SDKRepositories: map[string]config.SDKRepository{"temurin": {Type: "jdk"}},
+	}
+
+	homes = make(map[string]string)
+	for _, version := range []string{"17.0.9", "21.0.6"} {
+		home := filepath.Join(installDir, "jdks", "temurin", version, "jdk-"+version)
+		if err := os.MkdirAll(filepath.Join(home, "bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(home, "release"), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(home, "bin", "java"), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		homes[version] = home
+	}
+	if err := os.Symlink(homes["21.0.6"], filepath.Join(installDir, "current-jdk")); err != nil {
+		t.Fatal(err)
+	}
+	return installDir, homes
+}
+
+func TestVersionOverrideVariable(t *testing.T) {
+	tests := []struct {
+		sdkType string
+		want    string
+	}{
+		{"jdk", "STRIGO_JDK_VERSION"},
+		{"node", "STRIGO_NODE_VERSION"},
+		{"graal-vm", "STRIGO_GRAAL_VM_VERSION"},
+	}
+	for _, tt := range tests {
+		if got := versionOverrideVariable(tt.sdkType); got != tt.want {
+			t.Errorf("versionOverrideVariable(%q) = %q, want %q", tt.sdkType, got, tt.want)
+		}
+	}
+}
+
+func TestShimTarget(t *testing.T) {
+	_, homes := setupShimSDKs(t)
+
+	tests := []struct {
+		name     string
+		override string
+		tool     string
+		want     string // Expected home, empty when an error is expected
+		source   string
+	}{
+		{"global selection", "", "java", homes["21.0.6"], sourceGlobal},
+		{"override with distribution", "temurin:17", "java", homes["17.0.9"], "STRIGO_JDK_VERSION"},
+		{"override without distribution", "17.0.9", "java", homes["17.0.9"], "STRIGO_JDK_VERSION"},
+		{"override not installed", "temurin:11", "java", "", ""},
+		{"tool not provided", "", "jlink", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("STRIGO_JDK_VERSION", tt.override)
+
+			path, active, err := shimTarget("jdk", tt.tool)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("shimTarget = %s, want an error", path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("shimTarget: %v", err)
+			}
+			if want := filepath.Join(tt.want, "bin", tt.tool); path != want {
+				t.Errorf("path = %s, want %s", path, want)
+			}
+			if active.Source != tt.source {
+				t.Errorf("source = %s, want %s", active.Source, tt.source)
+			}
+		})
+	}
+}
+
+func TestWriteShims(t *testing.T) {
+	installDir, _ := setupShimSDKs(t)
+	dir := filepath.Join(installDir, shimsDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A shim of a tool no longer installed, and a file the user put there
+	stale := filepath.Join(dir, "jshell")
+	if err := os.WriteFile(stale, []byte("#!/bin/sh\n"+shimMarker+" for jshell (jdk)\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	userFile := filepath.Join(dir, "my-script")
+	if err := os.WriteFile(userFile, []byte("#!/bin/sh\necho mine\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := writeShims()
+	if err != nil {
+		t.Fatalf("writeShims: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale shim was not removed")
+	}
+	if _, err := os.Stat(userFile); err != nil {
+		t.Errorf("file not generated by strigo was removed: %v", err)
+	}
+
+	java, err := os.ReadFile(filepath.Join(dir, "java"))
+	if err != nil {
+		t.Fatalf("java shim: %v", err)
+	}
+	for _, want := range []string{
+		shimMarker + " for java (jdk)",
+		`if [ -z "${STRIGO_JDK_VERSION-}" ] && [ -x ` + posixQuote(filepath.Join(installDir, "current-jdk", "bin", "java")) + ` ]; then`,
+		"shim-exec jdk java -- \"$@\"",
+	} {
+		if !strings.Contains(string(java), want) {
+			t.Errorf("java shim does not contain %q:\n%s", want, java)
+		}
+	}
+	if !isExecutable(filepath.Join(dir, "java")) {
+		t.Error("java shim is not executable")
+	}
+}
@@ -4,20 +4,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strigo/installation"
 	"strigo/logging"
+	sdkversion "strigo/version"
 	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	setEnvVar bool
-	unsetEnv  bool
+	setEnvVar   bool
+	unsetEnv    bool
+	rcShellFlag string
 )
 
 func init() {
-	useCmd.Flags().BoolVarP(&setEnvVar, "set-env", "e", false, "Set environment variables in shell configuration file (~/.bashrc or ~/.zshrc)")
+	useCmd.Flags().BoolVarP(&setEnvVar, "set-env", "e", false, "Set environment variables in shell configuration file (~/.bashrc, ~/.zshrc, fish conf.d or nushell env.nu)")
 	useCmd.Flags().BoolVar(&unsetEnv, "unset", false, "Remove environment variables from shell configuration file")
+	useCmd.Flags().StringVar(&rcShellFlag, "shell", "", "Shell whose configuration file is edited: bash, zsh, fish or nu (defaults to $SHELL)")
 }
 
 var useCmd = &cobra.Command{
@@ -69,79 +73,135 @@ func use(cmd *cobra.Command, args []string) {
 	}
 }
 
-func getSDKBinPath(basePath string, sdkType string) (string, error) {
-	entries, err := os.ReadDir(basePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read installation directory: %w", err)
-	}
-
-	// SDK directory selection logic
-	var sdkDir string
-	dirCount := 0
-	
-	// Count directories and remember the first one
-	for _, entry := range entries {
-		if entry.IsDir() {
-			dirCount++
-			// If it's the first directory, remember it
-			if sdkDir == "" {
-				sdkDir = entry.Name()
-			}
+// rcShell describes how strigo edits the configuration file of a shell
+type rcShell struct {
+	files []string // Candidate files relative to the home directory: the first existing one is used, else the first one is created
+	block func(envVar, sdkPath string) string
+}
+
+// rcShells lists the shells whose configuration file strigo can edit
+var rcShells = map[string]rcShell{
+	"bash": {files: []string{".bashrc", ".zshrc"}, block: posixBlock},
+	"zsh":  {files: []string{".zshrc", ".bashrc"}, block: posixBlock},
+	"fish": {files: []string{".config/fish/conf.d/strigo.fish"}, block: fishBlock},
+	"nu":   {files: []string{".config/nushell/env.nu"}, block: nuBlock},
+}
+
+// rcMarker returns the comment starting the block strigo writes for an SDK type
+func rcMarker(sdkType string) string {
+	return fmt.Sprintf("# Added by Strigo - %s configuration", strings.ToUpper(sdkType))
+}
+
+func posixBlock(envVar, sdkPath string) string {
+	return fmt.Sprintf("export %s=%s\nexport PATH=$%s/bin:$PATH", envVar, sdkPath, envVar)
+}
+
+func fishBlock(envVar, sdkPath string) string {
+	return fmt.Sprintf("set -gx %s %s\nfish_add_path --global --move $%s/bin", envVar, fishQuote(sdkPath), envVar)
+}
+
+func nuBlock(envVar, sdkPath string) string {
+	return fmt.Sprintf("$env.%s = %s\n$env.PATH = ($env.PATH | split row (char esep) | prepend ($env.%s | path join bin))",
+		envVar, doubleQuote(sdkPath), envVar)
+}
+
+// rcShellName returns the shell whose configuration is edited: the --shell flag,
+// else the extension of shell_config_path, else $SHELL
+func rcShellName() (string, error) {
+	if rcShellFlag != "" {
+		if _, supported := rcShells[rcShellFlag]; !supported {
+			return "", fmt.Errorf("unsupported shell %q (supported: bash, zsh, fish, nu)", rcShellFlag)
 		}
-	}
-	
-	// If multiple directories exist, it's ambiguous
-	if dirCount > 1 {
-		sdkDir = ""
+		return rcShellFlag, nil
 	}
 
-	if sdkDir == "" {
-		return "", fmt.Errorf("could not find %s directory in %s", strings.ToUpper(sdkType), basePath)
+	if cfg.General.ShellConfigPath != "" {
+		switch filepath.Ext(cfg.General.ShellConfigPath) {
+		case ".fish":
+			return "fish", nil
+		case ".nu":
+			return "nu", nil
+		}
+		return "bash", nil
 	}
 
-	return filepath.Join(basePath, sdkDir), nil
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if _, supported := rcShells[shell]; supported {
+		return shell, nil
+	}
+	return "", nil
 }
 
-func findRcFile() (string, error) {
+// findRcFile returns the configuration file to edit and the shell it belongs to
+func findRcFile() (string, string, error) {
+	shell, err := rcShellName()
+	if err != nil {
+		return "", "", err
+	}
+
 	// Check if shell_config_path is set in config
 	if cfg.General.ShellConfigPath != "" {
-		return cfg.General.ShellConfigPath, nil
+		return cfg.General.ShellConfigPath, shell, nil
 	}
 
-	// Auto-detect based on current shell
-	shell := os.Getenv("SHELL")
-	home := os.Getenv("HOME")
-
-	// List of possible RC files
-	var rcFiles []string
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
 
-	// Determine the order based on the shell
-	if strings.HasSuffix(shell, "zsh") {
-		rcFiles = []string{
-			filepath.Join(home, ".zshrc"),
-			filepath.Join(home, ".bashrc"), // fallback
-		}
-	} else if strings.HasSuffix(shell, "bash") {
-		rcFiles = []string{
-			filepath.Join(home, ".bashrc"),
-			filepath.Join(home, ".zshrc"), // fallback
+	// Unrecognized shell, try both bash and zsh
+	detected := shell != ""
+	if !detected {
+		shell = "bash"
+	}
+	rc := rcShells[shell]
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	var paths []string
+	for _, file := range rc.files {
+		path := filepath.Join(home, file)
+		if configHome != "" && strings.HasPrefix(file, ".config/") {
+			path = filepath.Join(configHome, strings.TrimPrefix(file, ".config/"))
 		}
-	} else {
-		// Unrecognized shell, try both
-		rcFiles = []string{
-			filepath.Join(home, ".bashrc"),
-			filepath.Join(home, ".zshrc"),
+		if _, err := os.Stat(path); err == nil {
+			return path, shell, nil
 		}
+		paths = append(paths, path)
+	}
+
+	// The file of a known shell is created on demand
+	if detected {
+		return paths[0], shell, nil
 	}
+	return "", "", fmt.Errorf("no shell configuration file found (.zshrc or .bashrc). Please set shell_config_path in strigo.toml or use --shell")
+}
 
-	// Find the first existing RC file
-	for _, file := range rcFiles {
-		if _, err := os.Stat(file); err == nil {
-			return file, nil
+// removeRcBlock removes the block strigo wrote for an SDK type: the marker and the two lines following it
+func removeRcBlock(content, sdkType string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	var newLines []string
+	removed := false
+	for i := 0; i < len(lines); i++ {
+		if strings.Contains(lines[i], rcMarker(sdkType)) {
+			i += 2 // +2 because the loop will do +1
+			removed = true
+			continue
 		}
+		newLines = append(newLines, lines[i])
 	}
+	return strings.Join(newLines, "\n"), removed
+}
 
-	return "", fmt.Errorf("no shell configuration file found (.zshrc or .bashrc). Please set shell_config_path in strigo.toml")
+// warnLeftoverHomeLines reports the lines still mentioning a home variable once
+// the strigo block is gone. They were not written by strigo and are left in place.
+func warnLeftoverHomeLines(content, envVar, path string) {
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || !strings.Contains(line, envVar) {
+			continue
+		}
+		logging.LogInfo("⚠️  %s:%d still mentions %s and was left unchanged: %s", path, i+1, envVar, line)
+	}
 }
 
 func handleUnset(sdkType string) error {
@@ -153,55 +213,39 @@ func handleUnset(sdkType string) error {
 		return fmt.Errorf("unset is only supported for JDK and Node.js")
 	}
 
-	rcFile, err := findRcFile()
+	expandedPath, _, err := findRcFile()
 	if err != nil {
 		return fmt.Errorf("could not find shell configuration file: %w", err)
 	}
 
-	// Expand tilde if present
-	expandedPath := rcFile
-	if strings.HasPrefix(rcFile, "~") {
-		home := os.Getenv("HOME")
-		if home == "" {
-			return fmt.Errorf("HOME environment variable not set")
-		}
-		expandedPath = filepath.Join(home, rcFile[1:])
-	}
-
 	// Read the current content
 	content, err := os.ReadFile(expandedPath)
+	if os.IsNotExist(err) {
+		logging.LogInfo("ℹ️  No Strigo %s configuration found in %s", strings.ToUpper(sdkType), expandedPath)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to read %s: %w", expandedPath, err)
 	}
 
-	// Remove the Strigo configuration block
-	lines := strings.Split(string(content), "\n")
-	var newLines []string
-	var removed bool
-	for i := 0; i < len(lines); i++ {
-		line := lines[i]
-		// If we find the Strigo comment
-		if strings.Contains(line, fmt.Sprintf("# Added by Strigo - %s configuration", strings.ToUpper(sdkType))) {
-			// Skip this line and the next two
-			i += 2 // +2 because the loop will do +1
-			removed = true
-			continue
-		}
-		newLines = append(newLines, line)
-	}
-
+	// Remove the Strigo configuration block, leaving lines written by hand alone
+	newContent, removed := removeRcBlock(string(content), sdkType)
 	if !removed {
-		logging.LogInfo("ℹ️  No Strigo %s configuration found in %s", strings.ToUpper(sdkType), rcFile)
+		logging.LogInfo("ℹ️  No Strigo %s configuration found in %s", strings.ToUpper(sdkType), expandedPath)
+		warnLeftoverHomeLines(newContent, homeVariable(sdkType), expandedPath)
 		return nil
 	}
 
 	// Write the file
-	newContent := strings.Join(newLines, "\n") + "\n"
+	if newContent = strings.TrimRight(newContent, "\n"); newContent != "" {
+		newContent += "\n"
+	}
 	if err := os.WriteFile(expandedPath, []byte(newContent), 0644); err != nil {
 		return fmt.Errorf("failed to update %s: %w", expandedPath, err)
 	}
 
 	logging.LogInfo("✅ Successfully removed Strigo %s configuration from %s", strings.ToUpper(sdkType), expandedPath)
+	warnLeftoverHomeLines(newContent, homeVariable(sdkType), expandedPath)
 	logging.LogInfo("ℹ️  To apply these changes, run: source %s", expandedPath)
 
 	return nil
@@ -218,16 +262,22 @@ func handleUse(sdkType, distribution, version string) error {
 		return fmt.Errorf("SDK type %s not found in configuration", sdkType)
 	}
 
-	// Build the installation path
-	installPath := filepath.Join(cfg.General.SDKInstallDir, sdkTypeConfig.InstallDir, distribution, version)
-
-	// Check if the SDK is installed
-	if _, err := os.Stat(installPath); os.IsNotExist(err) {
-		return fmt.Errorf("version %s %s %s is not installed", sdkType, distribution, version)
+	// Resolve aliases (latest, lts, 21, >=17 <22) among the installed versions
+	distributionPath := filepath.Join(cfg.General.SDKInstallDir, sdkTypeConfig.InstallDir, distribution)
+	requested := version
+	version, err := sdkversion.Resolve(requested, visibleSubdirectories(distributionPath), sdkTypeConfig.LTSMajors())
+	if err != nil {
+		return fmt.Errorf("version %s %s %s is not installed", sdkType, distribution, requested)
+	}
+	if version != requested {
+		logging.LogInfo("🎯 Resolved %s to installed version %s", requested, version)
 	}
 
-	// Get the binary path
-	sdkPath, err := getSDKBinPath(installPath, sdkType)
+	// Build the installation path
+	installPath := filepath.Join(distributionPath, version)
+
+	// Get the SDK home recorded at install time
+	sdkPath, err := installation.Home(installPath, sdkType)
 	if err != nil {
 		return fmt.Errorf("failed to find SDK binary path: %w", err)
 	}
@@ -261,12 +311,14 @@ func handleUse(sdkType, distribution, version string) error {
 			logging.LogInfo("   export PATH=$JAVA_HOME/bin:$PATH")
 			logging.LogInfo("")
 			logging.LogInfo("💡 Or use --set-env to set them automatically in your shell configuration")
+			logging.LogInfo("💡 Or load them in the current shell with: eval \"$(strigo env)\"")
 		} else if sdkType == "node" {
 			logging.LogInfo("ℹ️  To use this Node.js version, set these environment variables:")
 			logging.LogInfo("   export NODE_HOME=%s", sdkPath)
 			logging.LogInfo("   export PATH=$NODE_HOME/bin:$PATH")
 			logging.LogInfo("")
 			logging.LogInfo("💡 Or use --set-env to set them automatically in your shell configuration")
+			logging.LogInfo("💡 Or load them in the current shell with: eval \"$(strigo env)\"")
 		}
 	}
 
@@ -274,55 +326,34 @@ func handleUse(sdkType, distribution, version string) error {
 }
 
 func configureEnvironment(sdkType, sdkPath string) error {
+	envVar := homeVariable(sdkType)
+	if envVar == "" {
+		return fmt.Errorf("environment configuration is only supported for JDK and Node.js")
+	}
+
 	// Find the appropriate RC file
-	rcFile, err := findRcFile()
+	expandedPath, shell, err := findRcFile()
 	if err != nil {
 		return err
 	}
 
-	// Expand tilde if present
-	expandedPath := rcFile
-	if strings.HasPrefix(rcFile, "~") {
-		home := os.Getenv("HOME")
-		if home == "" {
-			return fmt.Errorf("HOME environment variable not set")
-		}
-		expandedPath = filepath.Join(home, rcFile[1:])
-	}
-
 	// Read the current content
 	content, err := os.ReadFile(expandedPath)
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to read rc file: %w", err)
 	}
 
-	// Prepare the new lines
-	var envVar string
-	if sdkType == "jdk" {
-		envVar = "JAVA_HOME"
-	} else if sdkType == "node" {
-		envVar = "NODE_HOME"
+	// Replace the old configuration if it exists
+	newContent, _ := removeRcBlock(string(content), sdkType)
+	if newContent = strings.TrimRight(newContent, "\n"); newContent != "" {
+		newContent += "\n\n"
 	}
-
-	newConfig := fmt.Sprintf("\n# Added by Strigo - %s configuration\nexport %s=%s\nexport PATH=$%s/bin:$PATH\n",
-		strings.ToUpper(sdkType), envVar, sdkPath, envVar)
-
-	// Remove the old configuration if it exists
-	lines := strings.Split(string(content), "\n")
-	var newLines []string
-	for i := 0; i < len(lines); i++ {
-		line := lines[i]
-		if strings.Contains(line, fmt.Sprintf("# Added by Strigo - %s configuration", strings.ToUpper(sdkType))) {
-			i += 2 // Skip next two lines
-			continue
-		}
-		newLines = append(newLines, line)
-	}
-
-	// Add the new configuration
-	newContent := strings.Join(newLines, "\n") + newConfig
+	newContent += fmt.Sprintf("%s\n%s\n", rcMarker(sdkType), rcShells[shell].block(envVar, sdkPath))
 
 	// Write the new content
+	if err := os.MkdirAll(filepath.Dir(expandedPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(expandedPath), err)
+	}
 	if err := os.WriteFile(expandedPath, []byte(newContent), 0644); err != nil {
 		return fmt.Errorf("failed to update rc file: %w", err)
 	}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strigo/config"
 	"strigo/logging"
+	sdkversion "strigo/version"
+	"strings"
 )
 
 // ListOutput structure for JSON output of list and available commands
@@ -55,3 +58,68 @@ func ExitWithError(err error) {
 	}
 	os.Exit(1)
 }
+
+// installedSDK identifies an installed SDK version
+type installedSDK struct {
+	SDKType      string
+	Distribution string
+	Version      string
+	Path         string
+}
+
+// findInstallations lists the installed versions matching the optional
+// [type] [distribution] [version] filters
+func findInstallations(cfg *config.Config, filters []string) ([]installedSDK, error) {
+	var sdkTypes []string
+	for sdkType := range cfg.SDKTypes {
+		if len(filters) == 0 || filters[0] == sdkType {
+			sdkTypes = append(sdkTypes, sdkType)
+		}
+	}
+	if len(filters) > 0 && len(sdkTypes) == 0 {
+		return nil, fmt.Errorf("SDK type %s not found in configuration", filters[0])
+	}
+	sort.Strings(sdkTypes)
+
+	var installed []installedSDK
+	for _, sdkType := range sdkTypes {
+		typePath := filepath.Join(cfg.General.SDKInstallDir, cfg.SDKTypes[sdkType].InstallDir)
+		for _, distribution := range visibleSubdirectories(typePath) {
+			if len(filters) > 1 && filters[1] != distribution {
+				continue
+			}
+			versions := visibleSubdirectories(filepath.Join(typePath, distribution))
+			sdkversion.Sort(versions)
+			for _, version := range versions {
+				if len(filters) > 2 && filters[2] != version {
+					continue
+				}
+				installed = append(installed, installedSDK{
+					SDKType:      sdkType,
+					Distribution: distribution,
+					Version:      version,
+					Path:         filepath.Join(typePath, distribution, version),
+				})
+			}
+		}
+	}
+	return installed, nil
+}
+
+// visibleSubdirectories lists the subdirectories of a path, skipping hidden
+// entries such as staging directories of installations in progress
+func visibleSubdirectories(path string) []string {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strigo/installation"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit code of strigo verify when an installation differs from its manifest
+const exitCodeDrift = 2
+
+// Verification statuses
+const (
+	verifyStatusOK         = "ok"
+	verifyStatusDrift      = "drift"
+	verifyStatusNoManifest = "no_manifest"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [type] [distribution] [version]",
+	Short: "Check installed SDKs against the file manifest captured at install time",
+	Long: `Check installed SDKs against the per-file hash manifest captured at install time,
+reporting modified, missing and extra files. For example:
+strigo verify                          # Verify every installed SDK
+strigo verify jdk                      # Verify all installed JDKs
+strigo verify jdk temurin 21.0.6_7     # Verify a single version
+
+Exit codes: 0 when every installation matches, 2 when a difference is found, 1 on error.`,
+	Args: cobra.MaximumNArgs(3),
+	Run:  verify,
+	Example: `  # Verify every installed SDK
+  strigo verify
+
+  # Verify Temurin JDKs in CI, as JSON
+  strigo verify jdk temurin --json`,
+}
+
+// VerifyResult is the verification outcome of one installation
+type VerifyResult struct {
+	SDKType      string   `json:"sdk_type"`
+	Distribution string   `json:"distribution"`
+	Version      string   `json:"version"`
+	Path         string   `json:"path"`
+	Status       string   `json:"status"`
+	Modified     []string `json:"modified,omitempty"`
+	Missing      []string `json:"missing,omitempty"`
+	Extra        []string `json:"extra,omitempty"`
+}
+
+func verify(cmd *cobra.Command, args []string) {
+	drift, err := handleVerify(args)
+	if err != nil {
+		ExitWithError(err)
+	}
+	if drift {
+		os.Exit(exitCodeDrift)
+	}
+}
+
+// handleVerify verifies the matching installations and reports whether any of them drifted
+func handleVerify(args []string) (bool, error) {
+	installed, err := findInstallations(cfg, args)
+	if err != nil {
+		return false, err
+	}
+	if len(args) == 3 && len(installed) == 0 {
+		return false, fmt.Errorf("version %s %s %s is not installed", args[0], args[1], args[2])
+	}
+
+	results := []VerifyResult{}
+	drifted := false
+	for _, sdk := range installed {
+		result := VerifyResult{
+			SDKType:      sdk.SDKType,
+			Distribution: sdk.Distribution,
+			Version:      sdk.Version,
+			Path:         sdk.Path,
+		}
+
+		drift, err := installation.Verify(sdk.Path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			result.Status = verifyStatusNoManifest
+		case err != nil:
+			return false, err
+		case drift.IsClean():
+			result.Status = verifyStatusOK
+		default:
+			result.Status = verifyStatusDrift
+			result.Modified = drift.Modified
+			result.Missing = drift.Missing
+			result.Extra = drift.Extra
+			drifted = true
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput {
+		return drifted, OutputJSON(results)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No installed SDKs to verify")
+		return false, nil
+	}
+
+	for _, result := range results {
+		name := fmt.Sprintf("%s %s %s", result.SDKType, result.Distribution, result.Version)
+		switch result.Status {
+		case verifyStatusOK:
+			fmt.Printf("✅ %s\n", name)
+		case verifyStatusNoManifest:
+			fmt.Printf("⚠️  %s: no manifest (installed before manifests were recorded)\n", name)
+		default:
+			fmt.Printf("❌ %s: %d modified, %d missing, %d extra\n", name, len(result.Modified), len(result.Missing), len(result.Extra))
+			printPaths("modified", result.Modified)
+			printPaths("missing", result.Missing)
+			printPaths("extra", result.Extra)
+		}
+	}
+
+	return drifted, nil
+}
+
+func printPaths(label string, paths []string) {
+	for _, path := range paths {
+		fmt.Printf("    %-8s %s\n", label, path)
+	}
+}
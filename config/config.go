@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strigo/logging"
 	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml"
 )
@@ -20,26 +21,66 @@ type GeneralConfig struct {
 	JDKSecurityPath   string `toml:"jdk_security_path"`
 	SystemCacertsPath string `toml:"system_cacerts_path"`
 	ShellConfigPath   string `toml:"shell_config_path"`
+	DownloadRetries   int    `toml:"download_retries"`
+	RetryBackoff      string `toml:"retry_backoff"`
+	MaxExtractBytes   int64  `toml:"max_extract_bytes"`
+	MaxExtractFiles   int64  `toml:"max_extract_files"`
 }
 
 // SDKType represents a referenced SDK type configuration
 type SDKType struct {
-	Type       string `toml:"type"`
-	InstallDir string `toml:"install_dir"`
+	Type       string            `toml:"type"`
+	InstallDir string            `toml:"install_dir"`
+	LTS        []int             `toml:"lts"` // Major versions selected by the "lts" alias
+	Env        map[string]string `toml:"env"` // Extra variables set for an SDK, "{home}" standing for its home
+}
+
+// HomePlaceholder is replaced by the SDK home in the values of SDKType.Env
+const HomePlaceholder = "{home}"
+
+// defaultLTS lists the long-term support majors used when an SDK type does not configure them
+var defaultLTS = map[string][]int{
+	"jdk":  {8, 11, 17, 21, 25},
+	"node": {18, 20, 22, 24},
+}
+
+// LTSMajors returns the major versions selected by the "lts" alias
+func (t SDKType) LTSMajors() []int {
+	if len(t.LTS) > 0 {
+		return t.LTS
+	}
+	return defaultLTS[t.Type]
+}
+
+// Environment returns the extra variables of an SDK installed at home
+func (t SDKType) Environment(home string) map[string]string {
+	env := make(map[string]string, len(t.Env))
+	for name, value := range t.Env {
+		env[name] = strings.ReplaceAll(value, HomePlaceholder, home)
+	}
+	return env
 }
 
 // Registry represents a remote registry configuration
 type Registry struct {
-	Type   string `toml:"type"`
-	APIURL string `toml:"api_url"`
+	Type         string `toml:"type"`
+	APIURL       string `toml:"api_url"`
+	SearchAPIURL string `toml:"search_api_url"`
+	Auth         string `toml:"auth"`         // "basic", "bearer" or empty to infer from the credentials found
+	UsernameEnv  string `toml:"username_env"` // Environment variable holding the username
+	PasswordEnv  string `toml:"password_env"` // Environment variable holding the password
+	TokenEnv     string `toml:"token_env"`    // Environment variable holding the bearer token
 }
 
 // SDKRepository represents a referenced SDK configuration
 type SDKRepository struct {
-	Type       string `toml:"type"`
-	Registry   string `toml:"registry"`
-	Repository string `toml:"repository"`
-	Path       string `toml:"path"`
+	Type       string   `toml:"type"`
+	Registry   string   `toml:"registry"`
+	Repository string   `toml:"repository"`
+	Path       string   `toml:"path"`
+	Patterns   []string `toml:"patterns"` // Version extraction regexes with (?P<version>), (?P<os>) and (?P<arch>) groups
+	// Leading path components removed from archive entries, like tar --strip-components
+	StripComponents int `toml:"strip_components"`
 }
 
 // Config represents the main configuration structure
@@ -50,6 +91,15 @@ type Config struct {
 	SDKRepositories map[string]SDKRepository `toml:"sdk_repositories"`
 }
 
+// RetryBackoffDuration returns the parsed retry_backoff value
+func (g GeneralConfig) RetryBackoffDuration() time.Duration {
+	backoff, err := time.ParseDuration(g.RetryBackoff)
+	if err != nil {
+		return time.Second
+	}
+	return backoff
+}
+
 // ExpandTilde expands ~ to the user's home directory
 func ExpandTilde(path string) (string, error) {
 	if strings.HasPrefix(path, "~") {
@@ -83,8 +133,15 @@ func LoadConfig() (*Config, error) {
 	// Debug: Display raw file content
 	logging.PreLog("DEBUG", "📜 Raw file content:\n%s", string(file))
 
-	// Unmarshal TOML file
-	var cfg Config
+	// Unmarshal TOML file over the default values
+	cfg := Config{
+		General: GeneralConfig{
+			DownloadRetries: 3,
+			RetryBackoff:    "1s",
+			MaxExtractBytes: 8 << 30,
+			MaxExtractFiles: 500000,
+		},
+	}
 	err = toml.Unmarshal(file, &cfg)
 	if err != nil {
 		logging.PreLog("ERROR", "❌ Failed to parse config file: %v", err)
@@ -101,6 +158,18 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("one or more required configuration paths are empty")
 	}
 
+	if cfg.General.DownloadRetries < 0 {
+		return nil, fmt.Errorf("download_retries must not be negative")
+	}
+	if _, err := time.ParseDuration(cfg.General.RetryBackoff); err != nil {
+		return nil, fmt.Errorf("invalid retry_backoff %q: %w", cfg.General.RetryBackoff, err)
+	}
+	for name, repo := range cfg.SDKRepositories {
+		if repo.StripComponents < 0 {
+			return nil, fmt.Errorf("strip_components of repository %s must not be negative", name)
+		}
+	}
+
 	// Apply temporary log level to filter PreLog()
 	logging.SetPreLogLevel(cfg.General.LogLevel)
 
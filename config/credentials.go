@@ -0,0 +1,302 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strigo/logging"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+// Supported registry authentication schemes
+const (
+	AuthNone   = ""
+	AuthBasic  = "basic"
+	AuthBearer = "bearer"
+)
+
+// Credentials holds the resolved authentication data for a registry
+type Credentials struct {
+	Scheme   string
+	Username string
+	Password string
+	Token    string
+	Source   string
+}
+
+// Apply adds the Authorization header to an outgoing request
+func (c *Credentials) Apply(req *http.Request) {
+	if c == nil {
+		return
+	}
+	switch c.Scheme {
+	case AuthBasic:
+		req.SetBasicAuth(c.Username, c.Password)
+	case AuthBearer:
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+}
+
+// credentialsFileEntry represents a registry section of the credentials file
+type credentialsFileEntry struct {
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+	Token    string `toml:"token"`
+}
+
+// CredentialsFilePath returns the location of the credentials file
+func CredentialsFilePath() (string, error) {
+	if path := os.Getenv("STRIGO_CREDENTIALS_FILE"); path != "" {
+		return ExpandTilde(path)
+	}
+	return ExpandTilde("~/.config/strigo/credentials.toml")
+}
+
+// ResolveCredentials looks up the credentials of a registry.
+// Sources are tried in order: environment variables named in the registry
+// configuration, the credentials file, then ~/.netrc. A nil result means
+// the registry is accessed anonymously.
+func ResolveCredentials(name string, registry Registry) (*Credentials, error) {
+	scheme := strings.ToLower(registry.Auth)
+	if scheme != AuthNone && scheme != AuthBasic && scheme != AuthBearer {
+		return nil, fmt.Errorf("unsupported auth type %q for registry %s", registry.Auth, name)
+	}
+
+	entry, source, err := lookupCredentials(name, registry)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		if scheme != AuthNone {
+			return nil, fmt.Errorf("registry %s requires %s authentication but no credentials were found", name, scheme)
+		}
+		logging.LogDebug("🔓 No credentials found for registry %s, using anonymous access", name)
+		return nil, nil
+	}
+
+	// Infer the scheme from the available data when not configured
+	if scheme == AuthNone {
+		if entry.Token != "" {
+			scheme = AuthBearer
+		} else {
+			scheme = AuthBasic
+		}
+	}
+
+	creds := &Credentials{Scheme: scheme, Username: entry.Username, Password: entry.Password, Token: entry.Token, Source: source}
+	if scheme == AuthBearer && creds.Token == "" {
+		// netrc only knows about passwords, use it as the token
+		creds.Token = creds.Password
+	}
+
+	switch scheme {
+	case AuthBasic:
+		if creds.Username == "" {
+			return nil, fmt.Errorf("basic authentication for registry %s requires a username (from %s)", name, source)
+		}
+	case AuthBearer:
+		if creds.Token == "" {
+			return nil, fmt.Errorf("bearer authentication for registry %s requires a token (from %s)", name, source)
+		}
+	}
+
+	logging.RegisterSecret(creds.Password)
+	logging.RegisterSecret(creds.Token)
+	logging.LogDebug("🔐 Using %s authentication for registry %s (user=%q, source=%s)", scheme, name, creds.Username, source)
+	return creds, nil
+}
+
+func lookupCredentials(name string, registry Registry) (*credentialsFileEntry, string, error) {
+	// 1. Environment variables named in the configuration
+	entry := credentialsFileEntry{
+		Username: getenvIfSet(registry.UsernameEnv),
+		Password: getenvIfSet(registry.PasswordEnv),
+		Token:    getenvIfSet(registry.TokenEnv),
+	}
+	if entry.Username != "" || entry.Token != "" {
+		return &entry, "environment", nil
+	}
+
+	// 2. Credentials file
+	path, err := CredentialsFilePath()
+	if err != nil {
+		return nil, "", err
+	}
+	fileEntry, err := readCredentialsFile(path, name)
+	if err != nil {
+		return nil, "", err
+	}
+	if fileEntry != nil {
+		return fileEntry, path, nil
+	}
+
+	// 3. ~/.netrc
+	hosts := registryHosts(registry)
+	if len(hosts) == 0 {
+		return nil, "", nil
+	}
+	netrcPath := os.Getenv("NETRC")
+	if netrcPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, "", nil
+		}
+		netrcPath = filepath.Join(home, ".netrc")
+	}
+	netrcEntry, err := readNetrc(netrcPath, hosts...)
+	if err != nil {
+		return nil, "", err
+	}
+	if netrcEntry != nil {
+		return netrcEntry, netrcPath, nil
+	}
+
+	return nil, "", nil
+}
+
+func getenvIfSet(name string) string {
+	if name == "" {
+		return ""
+	}
+	return os.Getenv(name)
+}
+
+// registryHosts returns the hosts a registry is reached through, API first, then search API
+func registryHosts(registry Registry) []string {
+	var hosts []string
+	for _, rawURL := range []string{registry.APIURL, registry.SearchAPIURL} {
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		host := u.Hostname()
+		duplicate := false
+		for _, known := range hosts {
+			duplicate = duplicate || known == host
+		}
+		if !duplicate {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// readCredentialsFile reads the section of a registry in the credentials file.
+// The file must not be readable by group or others.
+func readCredentialsFile(path, name string) (*credentialsFileEntry, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat credentials file %s: %w", path, err)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("credentials file %s has permissions %04o, expected 0600", path, info.Mode().Perm())
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %s: %w", path, err)
+	}
+
+	var entries map[string]credentialsFileEntry
+	if err := toml.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s: %w", path, err)
+	}
+
+	entry, exists := entries[name]
+	if !exists || (entry.Username == "" && entry.Token == "") {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// readNetrc returns the login of the first of hosts found in a netrc file,
+// falling back to the default entry
+func readNetrc(path string, hosts ...string) (*credentialsFileEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netrc file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var tokens []string
+	inMacro := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// A macdef body runs until the next blank line and holds no tokens
+		if inMacro {
+			inMacro = line != ""
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		for i, field := range fields {
+			if field == "macdef" {
+				fields = fields[:i]
+				inMacro = true
+				break
+			}
+		}
+		tokens = append(tokens, fields...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read netrc file %s: %w", path, err)
+	}
+
+	machines := make(map[string]*credentialsFileEntry)
+	var fallback *credentialsFileEntry
+	var current *credentialsFileEntry
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "machine":
+			current = nil
+			if i+1 < len(tokens) {
+				i++
+				if _, seen := machines[tokens[i]]; !seen {
+					current = &credentialsFileEntry{}
+					machines[tokens[i]] = current
+				}
+			}
+		case "default":
+			current = nil
+			if fallback == nil {
+				fallback = &credentialsFileEntry{}
+				current = fallback
+			}
+		case "login":
+			if i+1 < len(tokens) {
+				i++
+				if current != nil {
+					current.Username = tokens[i]
+				}
+			}
+		case "password":
+			if i+1 < len(tokens) {
+				i++
+				if current != nil {
+					current.Password = tokens[i]
+				}
+			}
+		}
+	}
+
+	for _, host := range hosts {
+		if entry, exists := machines[host]; exists {
+			return entry, nil
+		}
+	}
+	return fallback, nil
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadNetrc(t *testing.T) {
+	netrc := `# corporate mirrors
+machine mirror.example.com login mirror password m1rr0r
+
+machine nexus.example.com
+    login jdoe
+    password s3cr3t
+
+macdef init
+machine unknown.example.com login macro password macro
+cd /pub
+
+machine search.example.com login searcher password s34rch
+default login anonymous password guest
+`
+	path := filepath.Join(t.TempDir(), "netrc")
+	writeFile(t, path, netrc, 0600)
+
+	tests := []struct {
+		name     string
+		hosts    []string
+		username string
+		password string
+	}{
+		{"machine on one line", []string{"mirror.example.com"}, "mirror", "m1rr0r"},
+		{"machine over several lines", []string{"nexus.example.com"}, "jdoe", "s3cr3t"},
+		{"machine after a macdef", []string{"search.example.com"}, "searcher", "s34rch"},
+		{"first host wins", []string{"nexus.example.com", "search.example.com"}, "jdoe", "s3cr3t"},
+		{"later host matches", []string{"unknown.example.com", "search.example.com"}, "searcher", "s34rch"},
+		{"default entry", []string{"unknown.example.com"}, "anonymous", "guest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := readNetrc(path, tt.hosts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if entry == nil {
+				t.Fatal("no entry found")
+			}
+			if entry.Username != tt.username || entry.Password != tt.password {
+				t.Errorf("got %s/%s, want %s/%s", entry.Username, entry.Password, tt.username, tt.password)
+			}
+		})
+	}
+}
+
+func TestReadNetrcWithoutMatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "netrc")
+	writeFile(t, path, "machine nexus.example.com login jdoe password s3cr3t\n", 0600)
+
+	entry, err := readNetrc(path, "unknown.example.com")
+	if err != nil || entry != nil {
+		t.Errorf("got %v, %v, want no entry", entry, err)
+	}
+	entry, err = readNetrc(filepath.Join(dir, "missing"), "nexus.example.com")
+	if err != nil || entry != nil {
+		t.Errorf("missing file: got %v, %v, want no entry", entry, err)
+	}
+}
+
+func TestReadCredentialsFilePermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.toml")
+	content := "[nexus]\nusername = \"jdoe\"\npassword = \"s3cr3t\"\n"
+
+	writeFile(t, path, content, 0644)
+	if _, err := readCredentialsFile(path, "nexus"); err == nil || !strings.Contains(err.Error(), "expected 0600") {
+		t.Errorf("0644 file: got %v, want a permission error", err)
+	}
+
+	writeFile(t, path, content, 0600)
+	entry, err := readCredentialsFile(path, "nexus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil || entry.Username != "jdoe" || entry.Password != "s3cr3t" {
+		t.Errorf("got %+v, want jdoe/s3cr3t", entry)
+	}
+}
+
+func TestRegistryHosts(t *testing.T) {
+	registry := Registry{
+		APIURL:       "https://nexus.example.com/service/rest/v1/assets?repository={repository}",
+		SearchAPIURL: "https://search.example.com/service/rest/v1/search/assets?repository={repository}",
+	}
+	if got := strings.Join(registryHosts(registry), ","); got != "nexus.example.com,search.example.com" {
+		t.Errorf("got %s", got)
+	}
+
+	registry.SearchAPIURL = "https://nexus.example.com/service/rest/v1/search/assets"
+	if got := strings.Join(registryHosts(registry), ","); got != "nexus.example.com" {
+		t.Errorf("same host: got %s", got)
+	}
+}
+
+func TestResolveCredentialsPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	credentialsPath := filepath.Join(dir, "credentials.toml")
+	netrcPath := filepath.Join(dir, "netrc")
+	t.Setenv("STRIGO_CREDENTIALS_FILE", credentialsPath)
+	t.Setenv("NETRC", netrcPath)
+	t.Setenv("STRIGO_TEST_USER", "")
+	t.Setenv("STRIGO_TEST_PASSWORD", "")
+
+	registry := Registry{
+		Type:         "nexus",
+		APIURL:       "https://nexus.example.com/service/rest/v1/assets?repository={repository}",
+		SearchAPIURL: "https://search.example.com/service/rest/v1/search/assets?repository={repository}",
+		UsernameEnv:  "STRIGO_TEST_USER",
+		PasswordEnv:  "STRIGO_TEST_PASSWORD",
+	}
+	resolve := func() *Credentials {
+		t.Helper()
+		creds, err := ResolveCredentials("nexus", registry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return creds
+	}
+
+	if creds := resolve(); creds != nil {
+		t.Fatalf("no source: got %+v, want anonymous access", creds)
+	}
+
+	writeFile(t, netrcPath, "machine search.example.com login from-netrc password n\n", 0600)
+	if creds := resolve(); creds == nil || creds.Username != "from-netrc" || creds.Source != netrcPath {
+		t.Errorf("netrc: got %+v", creds)
+	}
+
+	writeFile(t, credentialsPath, "[nexus]\nusername = \"from-file\"\npassword = \"f\"\n", 0600)
+	if creds := resolve(); creds == nil || creds.Username != "from-file" || creds.Source != credentialsPath {
+		t.Errorf("credentials file: got %+v", creds)
+	}
+
+	t.Setenv("STRIGO_TEST_USER", "from-env")
+	t.Setenv("STRIGO_TEST_PASSWORD", "e")
+	creds := resolve()
+	if creds == nil || creds.Username != "from-env" || creds.Source != "environment" {
+		t.Errorf("environment: got %+v", creds)
+	}
+	if creds != nil && creds.Scheme != AuthBasic {
+		t.Errorf("got scheme %q, want %q", creds.Scheme, AuthBasic)
+	}
+}
@@ -15,9 +15,14 @@ func NewManager() *Manager {
 	return &Manager{}
 }
 
+// CachePath retourne le répertoire de cache d'une version, sans le créer
+func (m *Manager) CachePath(sdkType, distribution, version, cacheDir string) string {
+	return filepath.Join(cacheDir, sdkType, distribution, version)
+}
+
 // PrepareCacheDirectory prépare le répertoire de cache
 func (m *Manager) PrepareCacheDirectory(sdkType, distribution, version, cacheDir string) (string, error) {
-	cachePath := filepath.Join(cacheDir, sdkType, distribution, version)
+	cachePath := m.CachePath(sdkType, distribution, version, cacheDir)
 	if err := os.MkdirAll(cachePath, 0755); err != nil {
 		return "", fmt.Errorf("failed to create cache directory: %w", err)
 	}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strigo/logging"
+	"strings"
+)
+
+// Checksums contient les empreintes attendues d'une archive
+type Checksums struct {
+	SHA256 string
+	SHA1   string
+}
+
+// IsEmpty indique si aucune empreinte n'est disponible
+func (c Checksums) IsEmpty() bool {
+	return c.SHA256 == "" && c.SHA1 == ""
+}
+
+// Verifier calcule les empreintes d'un flux au fil de l'eau
+type Verifier struct {
+	expected Checksums
+	sha256   hash.Hash
+	sha1     hash.Hash
+	writer   io.Writer
+}
+
+// NewVerifier crée une nouvelle instance de Verifier
+func NewVerifier(expected Checksums) *Verifier {
+	v := &Verifier{
+		expected: expected,
+		sha256:   sha256.New(),
+		sha1:     sha1.New(),
+	}
+	v.writer = io.MultiWriter(v.sha256, v.sha1)
+	return v
+}
+
+// Write implémente io.Writer
+func (v *Verifier) Write(p []byte) (int, error) {
+	return v.writer.Write(p)
+}
+
+// SHA256 retourne l'empreinte sha256 calculée
+func (v *Verifier) SHA256() string {
+	return hex.EncodeToString(v.sha256.Sum(nil))
+}
+
+// Verify compare les empreintes calculées aux empreintes attendues.
+// sha256 est privilégié, sha1 n'est utilisé qu'en l'absence de sha256.
+func (v *Verifier) Verify() error {
+	switch {
+	case v.expected.SHA256 != "":
+		actual := v.SHA256()
+		if !strings.EqualFold(actual, v.expected.SHA256) {
+			return fmt.Errorf("sha256 mismatch: expected %s, got %s", v.expected.SHA256, actual)
+		}
+		logging.LogDebug("🔐 sha256 verified: %s", actual)
+	case v.expected.SHA1 != "":
+		actual := hex.EncodeToString(v.sha1.Sum(nil))
+		if !strings.EqualFold(actual, v.expected.SHA1) {
+			return fmt.Errorf("sha1 mismatch: expected %s, got %s", v.expected.SHA1, actual)
+		}
+		logging.LogDebug("🔐 sha1 verified: %s", actual)
+	default:
+		logging.LogDebug("⚠️ No checksum available, skipping integrity check")
+	}
+	return nil
+}
+
+// VerifyFile vérifie l'intégrité d'un fichier sur disque et retourne son empreinte sha256
+func VerifyFile(path string, expected Checksums) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for verification: %w", err)
+	}
+	defer file.Close()
+
+	verifier := NewVerifier(expected)
+	if _, err := io.Copy(verifier, file); err != nil {
+		return "", fmt.Errorf("failed to read file for verification: %w", err)
+	}
+	return verifier.SHA256(), verifier.Verify()
+}
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"context"
+	"io"
+)
+
+// ContextReader interrompt la lecture d'un flux dès que le contexte est annulé
+type ContextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+// NewContextReader crée une nouvelle instance de ContextReader
+func NewContextReader(ctx context.Context, reader io.Reader) *ContextReader {
+	return &ContextReader{ctx: ctx, reader: reader}
+}
+
+// Read implémente io.Reader
+func (r *ContextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
@@ -18,6 +18,9 @@ func GetAvailableDiskSpace(path string) (uint64, error) {
 	return stat.Bavail * uint64(stat.Bsize), nil
 }
 
+// ExtractionRatio estimates the extracted size of an archive from its compressed size
+const ExtractionRatio = 2
+
 // CheckDiskSpace verifies if there is enough available disk space
 func CheckDiskSpace(requiredBytes int64, path string) error {
 	available, err := GetAvailableDiskSpace(path)
@@ -25,7 +28,7 @@ func CheckDiskSpace(requiredBytes int64, path string) error {
 		return err
 	}
 
-	required := uint64(requiredBytes * 2) // 2x for extraction buffer
+	required := uint64(requiredBytes)
 
 	if available < required {
 		return fmt.Errorf("need %d bytes, only %d bytes available", required, available)
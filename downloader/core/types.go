@@ -1,5 +1,10 @@
 package core
 
+import (
+	"strigo/config"
+	"time"
+)
+
 // CertConfig contient la configuration des certificats
 type CertConfig struct {
 	Enabled           bool
@@ -7,14 +12,35 @@ type CertConfig struct {
 	SystemCacertsPath string
 }
 
+// ExtractLimits borne la taille et le nombre de fichiers extraits d'une archive (0 = illimité)
+type ExtractLimits struct {
+	MaxBytes int64
+	MaxFiles int64
+}
+
+// DefaultExtractLimits est utilisée lorsque rien n'est configuré
+var DefaultExtractLimits = ExtractLimits{MaxBytes: 8 << 30, MaxFiles: 500000}
+
 // DownloadOptions contient les options pour le téléchargement et l'installation
 type DownloadOptions struct {
-	DownloadURL   string
-	CacheDir      string
-	InstallPath   string
-	SDKType       string
-	Distribution  string
-	Version       string
-	KeepCache     bool
-	CertConfig    CertConfig
+	DownloadURL     string
+	CacheDir        string
+	InstallPath     string
+	SDKType         string
+	Distribution    string
+	Version         string
+	KeepCache       bool
+	CertConfig      CertConfig
+	Checksums       Checksums
+	Credentials     *config.Credentials
+	Retries         int
+	RetryBackoff    time.Duration
+	ExtractLimits   ExtractLimits
+	StripComponents int
+}
+
+// DownloadResult décrit l'archive effectivement téléchargée et extraite
+type DownloadResult struct {
+	Size   int64
+	SHA256 string
 }
@@ -13,9 +13,9 @@ func NewValidator() *Validator {
 	return &Validator{}
 }
 
-// ValidateSpace vérifie l'espace disponible
-func (v *Validator) ValidateSpace(fileSize int64, directory string) error {
-	return CheckDiskSpace(fileSize, directory)
+// ValidateSpace vérifie que le répertoire peut accueillir requiredBytes octets
+func (v *Validator) ValidateSpace(requiredBytes int64, directory string) error {
+	return CheckDiskSpace(requiredBytes, directory)
 }
 
 // ValidateDirectories vérifie et crée les répertoires nécessaires
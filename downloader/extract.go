@@ -2,82 +2,125 @@ package downloader
 
 import (
 	"archive/tar"
-	"compress/gzip"
+	"archive/zip"
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"strigo/downloader/core"
 	"strigo/logging"
-
-	"github.com/ulikunitz/xz"
+	"strings"
+	"time"
 )
 
 // Extractor gère l'extraction des archives
-type Extractor struct{}
+type Extractor struct {
+	limits          core.ExtractLimits
+	stripComponents int
+}
 
 // NewExtractor crée une nouvelle instance d'Extractor
 func NewExtractor() *Extractor {
-	return &Extractor{}
+	return &Extractor{limits: core.DefaultExtractLimits}
+}
+
+// WithLimits retourne une copie de l'extracteur avec d'autres limites
+func (e *Extractor) WithLimits(limits core.ExtractLimits) *Extractor {
+	clone := *e
+	clone.limits = limits
+	return &clone
+}
+
+// WithStripComponents retourne une copie de l'extracteur qui retire les n premiers
+// composants du chemin de chaque entrée, comme tar --strip-components
+func (e *Extractor) WithStripComponents(n int) *Extractor {
+	clone := *e
+	clone.stripComponents = n
+	return &clone
 }
 
 // Extract extrait une archive vers un répertoire de destination
-func (e *Extractor) Extract(archivePath, destPath string) error {
+func (e *Extractor) Extract(ctx context.Context, archivePath, destPath string) error {
 	if !filepath.IsAbs(destPath) {
 		return fmt.Errorf("destination path must be absolute")
 	}
 
-	logging.LogDebug(" Starting extraction of %s to %s", filepath.Base(archivePath), destPath)
+	format, err := DetectFormat(archivePath)
+	if err != nil {
+		return err
+	}
+
+	logging.LogDebug(" Starting extraction of %s (%s) to %s", filepath.Base(archivePath), format, destPath)
 
-	switch {
-	case strings.HasSuffix(archivePath, ".tar.gz"):
-		return e.extractTarGz(archivePath, destPath)
-	case strings.HasSuffix(archivePath, ".tar.xz"):
-		return e.extractTarXz(archivePath, destPath)
-	default:
-		return fmt.Errorf("unsupported archive format")
+	if format == FormatZip {
+		return e.extractZip(ctx, archivePath, destPath)
 	}
+	return e.extractTarFile(ctx, format, archivePath, destPath)
 }
 
-func (e *Extractor) extractTarGz(tarPath, destPath string) error {
-	logging.LogDebug(" Opening tar.gz archive: %s", filepath.Base(tarPath))
-	file, err := os.Open(tarPath)
+// ErrStreamUnsupported indique qu'une archive ne peut pas être extraite au fil de l'eau
+// (une archive zip se lit depuis son répertoire central, en fin de fichier)
+var ErrStreamUnsupported = errors.New("archive format cannot be streamed")
+
+// ExtractStream extrait une archive tar, compressée ou non, directement depuis un flux.
+// name sert à identifier le format lorsque les octets magiques ne suffisent pas.
+func (e *Extractor) ExtractStream(ctx context.Context, r io.Reader, name, destPath string) error {
+	if !filepath.IsAbs(destPath) {
+		return fmt.Errorf("destination path must be absolute")
+	}
+
+	buffered := bufio.NewReaderSize(r, magicHeaderSize)
+	format, err := peekFormat(buffered, name)
 	if err != nil {
-		return fmt.Errorf("failed to open archive: %w", err)
+		return err
 	}
-	defer file.Close()
+	if format == FormatZip {
+		return ErrStreamUnsupported
+	}
+
+	logging.LogDebug(" Starting streamed extraction of %s (%s) to %s", name, format, destPath)
 
-	gzr, err := gzip.NewReader(file)
+	reader, err := decompress(format, buffered)
 	if err != nil {
-		return fmt.Errorf("failed to create gzip reader: %w", err)
+		return err
 	}
-	defer gzr.Close()
+	defer reader.Close()
 
-	return e.extractTar(tar.NewReader(gzr), destPath)
+	return e.extractTar(ctx, tar.NewReader(reader), destPath)
 }
 
-func (e *Extractor) extractTarXz(tarPath, destPath string) error {
-	logging.LogDebug(" Opening tar.xz archive: %s", filepath.Base(tarPath))
+func (e *Extractor) extractTarFile(ctx context.Context, format ArchiveFormat, tarPath, destPath string) error {
+	logging.LogDebug(" Opening %s archive: %s", format, filepath.Base(tarPath))
 	file, err := os.Open(tarPath)
 	if err != nil {
 		return fmt.Errorf("failed to open archive: %w", err)
 	}
 	defer file.Close()
 
-	xzr, err := xz.NewReader(file)
+	reader, err := decompress(format, file)
 	if err != nil {
-		return fmt.Errorf("failed to create xz reader: %w", err)
+		return err
 	}
+	defer reader.Close()
 
-	return e.extractTar(tar.NewReader(xzr), destPath)
+	return e.extractTar(ctx, tar.NewReader(reader), destPath)
 }
 
-func (e *Extractor) extractTar(tr *tar.Reader, destPath string) error {
-	var filesExtracted int
-	var totalSize int64
+func (e *Extractor) extractTar(ctx context.Context, tr *tar.Reader, destPath string) error {
+	x, err := e.newExtraction(destPath)
+	if err != nil {
+		return err
+	}
 
 	logging.LogDebug(" Extracting files...")
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		header, err := tr.Next()
 		if err == io.EOF {
 			break
@@ -86,43 +129,430 @@ func (e *Extractor) extractTar(tr *tar.Reader, destPath string) error {
 			return fmt.Errorf("failed to read tar header: %w", err)
 		}
 
-		target := filepath.Join(destPath, header.Name)
-		if !strings.HasPrefix(target, filepath.Clean(destPath)+string(os.PathSeparator)) {
+		name, ok := stripPath(header.Name, e.stripComponents)
+		if !ok {
+			continue
+		}
+		target, err := safeJoin(destPath, name)
+		if err != nil {
 			return fmt.Errorf("invalid tar path: %s", header.Name)
 		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
-			if err := os.MkdirAll(target, 0755); err != nil {
-				return fmt.Errorf("failed to create directory: %w", err)
+			if err := x.mkdir(target, header.ModTime); err != nil {
+				return err
 			}
 		case tar.TypeReg:
-			if err := e.extractFile(tr, target, header.Mode); err != nil {
+			if err := x.writeFile(tr, target, header.FileInfo().Mode(), header.ModTime); err != nil {
 				return fmt.Errorf("failed to extract file: %w", err)
 			}
-			filesExtracted++
-			totalSize += header.Size
+		case tar.TypeSymlink:
+			if err := x.symlink(target, header.Linkname); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			linkname, ok := stripPath(header.Linkname, e.stripComponents)
+			if !ok {
+				return fmt.Errorf("invalid hardlink %s -> %s: target removed by strip_components", header.Name, header.Linkname)
+			}
+			if err := x.hardlink(target, linkname); err != nil {
+				return err
+			}
+		default:
+			logging.LogDebug(" Skipping unsupported tar entry %s (type %c)", header.Name, header.Typeflag)
 		}
 	}
-	logging.LogDebug(" Extraction completed: %d files extracted, total size: %d bytes", filesExtracted, totalSize)
+
+	return x.finish()
+}
+
+func (e *Extractor) extractZip(ctx context.Context, zipPath, destPath string) error {
+	logging.LogDebug(" Opening zip archive: %s", filepath.Base(zipPath))
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer zr.Close()
+
+	x, err := e.newExtraction(destPath)
+	if err != nil {
+		return err
+	}
+
+	logging.LogDebug(" Extracting files...")
+	for _, entry := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		name, ok := stripPath(entry.Name, e.stripComponents)
+		if !ok {
+			continue
+		}
+		target, err := safeJoin(destPath, name)
+		if err != nil {
+			return fmt.Errorf("invalid zip path: %s", entry.Name)
+		}
+
+		mode := entry.Mode()
+		switch {
+		case mode.IsDir():
+			if err := x.mkdir(target, entry.Modified); err != nil {
+				return err
+			}
+		case mode&os.ModeSymlink != 0:
+			linkname, err := readZipEntry(entry)
+			if err != nil {
+				return err
+			}
+			if err := x.symlink(target, linkname); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			rc, err := entry.Open()
+			if err != nil {
+				return fmt.Errorf("failed to open zip entry %s: %w", entry.Name, err)
+			}
+			err = x.writeFile(rc, target, mode, entry.Modified)
+			rc.Close()
+			if err != nil {
+				return fmt.Errorf("failed to extract file: %w", err)
+			}
+		default:
+			logging.LogDebug(" Skipping unsupported zip entry %s (mode %s)", entry.Name, mode)
+		}
+	}
+
+	return x.finish()
+}
+
+// stripPath retire les n premiers composants d'un chemin d'archive ; ok vaut false
+// lorsque rien ne reste, l'entrée devant alors être ignorée
+func stripPath(name string, n int) (string, bool) {
+	if n <= 0 {
+		return name, true
+	}
+
+	var parts []string
+	for _, part := range strings.Split(filepath.ToSlash(name), "/") {
+		if part != "" && part != "." {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) <= n {
+		return "", false
+	}
+	return strings.Join(parts[n:], "/"), true
+}
+
+// readZipEntry lit le contenu d'une entrée de lien symbolique (la cible du lien)
+func readZipEntry(entry *zip.File) (string, error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open zip entry %s: %w", entry.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return "", fmt.Errorf("failed to read zip entry %s: %w", entry.Name, err)
+	}
+	return string(content), nil
+}
+
+// extraction conserve l'état d'une extraction en cours : limites, liens et dates des répertoires
+type extraction struct {
+	root     string
+	realRoot string
+	limits   core.ExtractLimits
+	files    int64
+	bytes    int64
+	links    []string
+	dirTimes map[string]time.Time
+}
+
+func (e *Extractor) newExtraction(destPath string) (*extraction, error) {
+	root := filepath.Clean(destPath)
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve destination directory: %w", err)
+	}
+	return &extraction{
+		root:     root,
+		realRoot: realRoot,
+		limits:   e.limits,
+		dirTimes: make(map[string]time.Time),
+	}, nil
+}
+
+// countEntry applique la limite du nombre de fichiers extraits
+func (x *extraction) countEntry() error {
+	x.files++
+	if x.limits.MaxFiles > 0 && x.files > x.limits.MaxFiles {
+		return fmt.Errorf("archive exceeds the maximum number of files (%d)", x.limits.MaxFiles)
+	}
 	return nil
 }
 
-func (e *Extractor) extractFile(tr io.Reader, path string, mode int64) error {
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+// ensureParent crée le répertoire parent d'une entrée à l'intérieur de la racine
+func (x *extraction) ensureParent(target string) error {
+	return x.mkdirAll(filepath.Dir(target))
+}
+
+// mkdirAll crée un répertoire composant par composant depuis la racine réelle, en
+// résolvant chaque lien rencontré : un lien menant hors de la racine est refusé
+// avant que le moindre répertoire ne soit créé au-delà
+func (x *extraction) mkdirAll(dir string) error {
+	rel, err := filepath.Rel(x.root, dir)
+	if err != nil || !isWithin(x.root, dir) {
+		return fmt.Errorf("path escapes destination: %s", dir)
+	}
+	if rel == "." {
+		return nil
+	}
+
+	current := x.realRoot
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		next := filepath.Join(current, part)
+		info, err := os.Lstat(next)
+		switch {
+		case os.IsNotExist(err):
+			if err := os.Mkdir(next, 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to create directory: %w", err)
+		case info.Mode()&os.ModeSymlink != 0:
+			resolved, err := filepath.EvalSymlinks(next)
+			if err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", next, err)
+			}
+			if !isWithin(x.realRoot, resolved) {
+				return fmt.Errorf("refusing to write %s through a link leaving the installation directory", dir)
+			}
+			if info, err := os.Stat(resolved); err != nil || !info.IsDir() {
+				return fmt.Errorf("failed to create directory %s: %s is not a directory", dir, next)
+			}
+			next = resolved
+		case !info.IsDir():
+			return fmt.Errorf("failed to create directory %s: %s is not a directory", dir, next)
+		}
+		current = next
+	}
+	return nil
+}
+
+func (x *extraction) mkdir(target string, modTime time.Time) error {
+	if err := x.mkdirAll(target); err != nil {
+		return err
+	}
+	if !modTime.IsZero() {
+		x.dirTimes[target] = modTime
+	}
+	return nil
+}
+
+func (x *extraction) writeFile(r io.Reader, target string, mode os.FileMode, modTime time.Time) error {
+	if err := x.countEntry(); err != nil {
+		return err
+	}
+	if err := x.ensureParent(target); err != nil {
+		return err
+	}
+
+	// setuid, setgid et sticky ne sont jamais conservés
+	perm := mode.Perm()
+
+	// Un lien existant ne doit pas être suivi lors de l'écriture
+	if info, err := os.Lstat(target); err == nil && !info.Mode().IsRegular() {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
 		return err
 	}
 
-	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, os.FileMode(mode))
+	var src io.Reader = r
+	if x.limits.MaxBytes > 0 {
+		// Lire un octet de plus que le reste autorisé pour détecter un dépassement
+		src = io.LimitReader(r, x.limits.MaxBytes-x.bytes+1)
+	}
+	written, err := io.Copy(f, src)
+	closeErr := f.Close()
+	x.bytes += written
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	if closeErr != nil {
+		return closeErr
+	}
+	if x.limits.MaxBytes > 0 && x.bytes > x.limits.MaxBytes {
+		return fmt.Errorf("archive exceeds the maximum extracted size (%d bytes)", x.limits.MaxBytes)
+	}
+
+	// Le umask ne doit pas altérer les permissions de l'archive
+	if err := os.Chmod(target, perm); err != nil {
+		return err
+	}
+	if !modTime.IsZero() {
+		if err := os.Chtimes(target, modTime, modTime); err != nil {
+			return err
+		}
+	}
+
+	logging.LogDebug(" Extracted: %s (%d bytes)", filepath.Base(target), written)
+	return nil
+}
+
+func (x *extraction) symlink(target, linkname string) error {
+	if err := x.countEntry(); err != nil {
+		return err
+	}
+	if filepath.IsAbs(linkname) {
+		return fmt.Errorf("invalid symlink %s -> %s: absolute targets are not allowed", target, linkname)
+	}
+	if err := x.ensureParent(target); err != nil {
+		return err
+	}
+	if err := x.checkLink(target, linkname); err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(target); err == nil {
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+	}
+	if err := os.Symlink(linkname, target); err != nil {
+		return fmt.Errorf("failed to create symlink: %w", err)
+	}
+
+	x.links = append(x.links, target)
+	logging.LogDebug(" Linked: %s -> %s", filepath.Base(target), linkname)
+	return nil
+}
 
-	written, err := io.Copy(f, tr)
+func (x *extraction) hardlink(target, linkname string) error {
+	if err := x.countEntry(); err != nil {
+		return err
+	}
+	source, err := safeJoin(x.root, linkname)
 	if err != nil {
+		return fmt.Errorf("invalid hardlink %s -> %s: target leaves the installation directory", target, linkname)
+	}
+	realSource, err := filepath.EvalSymlinks(source)
+	if err != nil {
+		return fmt.Errorf("invalid hardlink %s -> %s: %w", target, linkname, err)
+	}
+	if !isWithin(x.realRoot, realSource) {
+		return fmt.Errorf("invalid hardlink %s -> %s: target leaves the installation directory", target, linkname)
+	}
+	if err := x.ensureParent(target); err != nil {
 		return err
 	}
-	logging.LogDebug(" Extracted: %s (%d bytes)", filepath.Base(path), written)
+
+	if _, err := os.Lstat(target); err == nil {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+	if err := os.Link(realSource, target); err != nil {
+		return fmt.Errorf("failed to create hardlink: %w", err)
+	}
+
+	logging.LogDebug(" Hardlinked: %s -> %s", filepath.Base(target), linkname)
+	return nil
+}
+
+// checkLink résout la cible d'un lien composant par composant, en suivant les liens
+// déjà extraits, et refuse toute résolution hors du répertoire d'installation
+func (x *extraction) checkLink(target, linkname string) error {
+	current, err := filepath.EvalSymlinks(filepath.Dir(target))
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", filepath.Dir(target), err)
+	}
+
+	for _, part := range strings.Split(filepath.ToSlash(linkname), "/") {
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			current = filepath.Dir(current)
+		default:
+			current = filepath.Join(current, part)
+			if info, err := os.Lstat(current); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if resolved, err := filepath.EvalSymlinks(current); err == nil {
+					current = resolved
+				}
+			}
+		}
+		if !isWithin(x.realRoot, current) {
+			return fmt.Errorf("invalid symlink %s -> %s: target leaves the installation directory", target, linkname)
+		}
+	}
 	return nil
 }
+
+// finish vérifie les liens une fois toutes les cibles extraites, puis restaure les dates des répertoires
+func (x *extraction) finish() error {
+	// Un lien validé à sa création peut changer de sens lorsque des liens intermédiaires apparaissent ensuite
+	for _, link := range x.links {
+		linkname, err := os.Readlink(link)
+		if err != nil {
+			return fmt.Errorf("failed to read symlink %s: %w", link, err)
+		}
+		if err := x.checkLink(link, linkname); err != nil {
+			return err
+		}
+	}
+
+	// Les répertoires les plus profonds d'abord, pour ne pas modifier la date d'un parent déjà traité
+	dirs := make([]string, 0, len(x.dirTimes))
+	for dir := range x.dirTimes {
+		dirs = append(dirs, dir)
+	}
+	sortByDepth(dirs)
+	for _, dir := range dirs {
+		modTime := x.dirTimes[dir]
+		if err := os.Chtimes(dir, modTime, modTime); err != nil {
+			logging.LogDebug(" Failed to restore modification time of %s: %v", dir, err)
+		}
+	}
+
+	logging.LogDebug(" Extraction completed: %d entries extracted, total size: %d bytes", x.files, x.bytes)
+	return nil
+}
+
+// sortByDepth trie les chemins du plus profond au moins profond
+func sortByDepth(paths []string) {
+	depth := func(p string) int { return strings.Count(p, string(os.PathSeparator)) }
+	for i := 1; i < len(paths); i++ {
+		for j := i; j > 0 && depth(paths[j]) > depth(paths[j-1]); j-- {
+			paths[j], paths[j-1] = paths[j-1], paths[j]
+		}
+	}
+}
+
+// isWithin indique si path est root ou se trouve sous root
+func isWithin(root, path string) bool {
+	root = filepath.Clean(root)
+	path = filepath.Clean(path)
+	return path == root || strings.HasPrefix(path, root+string(os.PathSeparator))
+}
+
+// safeJoin résout le chemin d'une entrée d'archive en refusant toute sortie du répertoire de destination
+func safeJoin(destPath, name string) (string, error) {
+	root := filepath.Clean(destPath)
+	target := filepath.Join(root, name)
+	if !isWithin(root, target) {
+		return "", fmt.Errorf("path escapes destination: %s", name)
+	}
+	return target, nil
+}
@@ -0,0 +1,357 @@
+package downloader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strigo/downloader/core"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// tarEntry décrit une entrée d'archive de test
+type tarEntry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+}
+
+func dir(name string) tarEntry {
+	return tarEntry{name: name, typeflag: tar.TypeDir}
+}
+
+func file(name, body string) tarEntry {
+	return tarEntry{name: name, typeflag: tar.TypeReg, body: body}
+}
+
+func symlink(name, to string) tarEntry {
+	return tarEntry{name: name, typeflag: tar.TypeSymlink, linkname: to}
+}
+
+func hardlink(name, to string) tarEntry {
+	return tarEntry{name: name, typeflag: tar.TypeLink, linkname: to}
+}
+
+func buildTar(t *testing.T, entries ...tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Linkname: entry.linkname,
+			Mode:     0755,
+			Size:     int64(len(entry.body)),
+		}
+		if entry.typeflag != tar.TypeReg {
+			header.Size = 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("write header %s: %v", entry.name, err)
+		}
+		if _, err := io.WriteString(tw, entry.body); err != nil {
+			t.Fatalf("write body %s: %v", entry.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func buildZip(t *testing.T, entries ...tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		body := entry.body
+		switch entry.typeflag {
+		case tar.TypeDir:
+			header.SetMode(os.ModeDir | 0755)
+		case tar.TypeSymlink:
+			header.SetMode(os.ModeSymlink | 0777)
+			body = entry.linkname
+		default:
+			header.SetMode(0755)
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatalf("create zip entry %s: %v", entry.name, err)
+		}
+		if _, err := io.WriteString(w, body); err != nil {
+			t.Fatalf("write zip entry %s: %v", entry.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func compress(t *testing.T, format ArchiveFormat, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	var err error
+	switch format {
+	case FormatTar:
+		return data
+	case FormatTarGz:
+		w = gzip.NewWriter(&buf)
+	case FormatTarXz:
+		w, err = xz.NewWriter(&buf)
+	case FormatTarZst:
+		w, err = zstd.NewWriter(&buf)
+	default:
+		t.Fatalf("cannot compress %s", format)
+	}
+	if err != nil {
+		t.Fatalf("create %s writer: %v", format, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("compress %s: %v", format, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close %s writer: %v", format, err)
+	}
+	return buf.Bytes()
+}
+
+// extractArchive écrit l'archive sous un nom sans extension, le format devant être
+// reconnu par ses octets magiques, puis l'extrait dans un répertoire vide
+func extractArchive(t *testing.T, extractor *Extractor, data []byte) (string, error) {
+	t.Helper()
+	tmp := t.TempDir()
+	archive := filepath.Join(tmp, "archive")
+	if err := os.WriteFile(archive, data, 0644); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	dest := filepath.Join(tmp, "install")
+	return dest, extractor.Extract(context.Background(), archive, dest)
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(got) != want {
+		t.Errorf("%s = %q, want %q", path, got, want)
+	}
+}
+
+func assertMissing(t *testing.T, path string) {
+	t.Helper()
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Errorf("%s was created outside the installation directory", path)
+	}
+}
+
+// bz2Fixture est jdk-1/bin/java ("bz2\n") en tar.bz2, Go ne sachant pas compresser en bzip2
+const bz2Fixture = "QlpoOTFBWSZTWZEqjaIAAL37hMmQAFBAAv+AERB0OZ8QAACACCAAkoZUehAaADTQA0CKpqbTUyZB6gaaAyPU+NMbzCZFigF0hAZzLG4dbeRg2DuakQghOAan4wfT75tUZKyagHHLK1DY1MW2WKhRRghQLsie6YFRYwtCFQGpXOQIOPbx9GegnNZm6GQ/Rzb3Ro1jRo2YSRD+LuSKcKEhIlUbRA=="
+
+func TestExtractFormats(t *testing.T) {
+	entries := []tarEntry{dir("jdk-1/"), dir("jdk-1/bin/"), file("jdk-1/bin/java", "bz2\n")}
+	bz2, err := base64.StdEncoding.DecodeString(bz2Fixture)
+	if err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+
+	archives := map[ArchiveFormat][]byte{
+		FormatTar:    compress(t, FormatTar, buildTar(t, entries...)),
+		FormatTarGz:  compress(t, FormatTarGz, buildTar(t, entries...)),
+		FormatTarXz:  compress(t, FormatTarXz, buildTar(t, entries...)),
+		FormatTarZst: compress(t, FormatTarZst, buildTar(t, entries...)),
+		FormatTarBz2: bz2,
+		FormatZip:    buildZip(t, entries...),
+	}
+	for format, data := range archives {
+		t.Run(string(format), func(t *testing.T) {
+			if got := detectFormatFromHeader(data); got != format {
+				t.Fatalf("detected %q, want %q", got, format)
+			}
+			dest, err := extractArchive(t, NewExtractor(), data)
+			if err != nil {
+				t.Fatalf("Extract: %v", err)
+			}
+			assertFile(t, filepath.Join(dest, "jdk-1", "bin", "java"), "bz2\n")
+		})
+	}
+}
+
+func TestExtractStream(t *testing.T) {
+	data := compress(t, FormatTarGz, buildTar(t, file("jdk-1/bin/java", "java")))
+	dest := filepath.Join(t.TempDir(), "install")
+	if err := NewExtractor().ExtractStream(context.Background(), bytes.NewReader(data), "jdk.tar.gz", dest); err != nil {
+		t.Fatalf("ExtractStream: %v", err)
+	}
+	assertFile(t, filepath.Join(dest, "jdk-1", "bin", "java"), "java")
+
+	zipData := buildZip(t, file("jdk-1/bin/java", "java"))
+	err := NewExtractor().ExtractStream(context.Background(), bytes.NewReader(zipData), "jdk.zip", dest)
+	if !errors.Is(err, ErrStreamUnsupported) {
+		t.Errorf("ExtractStream(zip) = %v, want ErrStreamUnsupported", err)
+	}
+}
+
+func TestExtractStripComponents(t *testing.T) {
+	data := buildTar(t,
+		dir("jdk-21.0.6+7/"),
+		dir("jdk-21.0.6+7/bin/"),
+		file("jdk-21.0.6+7/bin/java", "java"),
+		file("jdk-21.0.6+7/release", "JAVA_VERSION=\"21.0.6\""),
+	)
+	dest, err := extractArchive(t, NewExtractor().WithStripComponents(1), data)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	assertFile(t, filepath.Join(dest, "bin", "java"), "java")
+	assertFile(t, filepath.Join(dest, "release"), "JAVA_VERSION=\"21.0.6\"")
+	assertMissing(t, filepath.Join(dest, "jdk-21.0.6+7"))
+}
+
+func TestExtractNodeRelativeLink(t *testing.T) {
+	data := buildTar(t,
+		symlink("node/bin/npm", "../lib/node_modules/npm/bin/npm-cli.js"),
+		file("node/lib/node_modules/npm/bin/npm-cli.js", "npm"),
+	)
+	dest, err := extractArchive(t, NewExtractor(), data)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	assertFile(t, filepath.Join(dest, "node", "bin", "npm"), "npm")
+}
+
+func TestExtractRejectsEscapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		escaped string // Chemin, relatif au parent de la racine, qui ne doit pas exister
+	}{
+		{
+			name:    "absolute symlink",
+			entries: []tarEntry{symlink("jdk/lib", "/etc"), file("jdk/lib/escaped", "x")},
+		},
+		{
+			name:    "parent symlink",
+			entries: []tarEntry{symlink("jdk/lib", "../.."), file("jdk/lib/escaped", "x")},
+			escaped: "escaped",
+		},
+		{
+			name:    "path traversal",
+			entries: []tarEntry{file("../escaped", "x")},
+			escaped: "escaped",
+		},
+		{
+			name:    "hardlink outside root",
+			entries: []tarEntry{hardlink("jdk/passwd", "../../etc/passwd")},
+		},
+		{
+			name:    "hardlink through symlink",
+			entries: []tarEntry{symlink("jdk/up", ".."), hardlink("jdk/passwd", "jdk/up/../escaped")},
+		},
+		{
+			// sub/a est valide à sa création car sub/b n'existe pas encore ;
+			// sub/b -> .. la fait ensuite pointer au-dessus de la racine
+			name: "link chain",
+			entries: []tarEntry{
+				dir("sub/"),
+				symlink("sub/a", "b/.."),
+				symlink("sub/b", ".."),
+				file("sub/a/a/b/../escaped-dir/x", "x"),
+			},
+			escaped: "a", // sub/a/a/escaped-dir une fois le chemin nettoyé
+		},
+		{
+			// Sans écriture au travers du lien, seule la vérification finale le détecte
+			name: "link chain without writes",
+			entries: []tarEntry{
+				dir("sub/"),
+				symlink("sub/a", "b/.."),
+				symlink("sub/b", ".."),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dest, err := extractArchive(t, NewExtractor(), buildTar(t, test.entries...))
+			if err == nil {
+				t.Fatal("Extract succeeded, want an error")
+			}
+			if test.escaped != "" {
+				assertMissing(t, filepath.Join(filepath.Dir(dest), test.escaped))
+			}
+		})
+	}
+}
+
+func TestMkdirAllRefusesLinkOutsideRoot(t *testing.T) {
+	tmp := t.TempDir()
+	x, err := NewExtractor().newExtraction(filepath.Join(tmp, "install"))
+	if err != nil {
+		t.Fatalf("newExtraction: %v", err)
+	}
+	if err := os.Symlink("..", filepath.Join(x.root, "up")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	if err := x.mkdirAll(filepath.Join(x.root, "up", "escaped-dir", "sub")); err == nil {
+		t.Fatal("mkdirAll succeeded, want an error")
+	}
+	assertMissing(t, filepath.Join(tmp, "escaped-dir"))
+}
+
+func TestExtractLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  core.ExtractLimits
+		entries []tarEntry
+		wantErr bool
+	}{
+		{
+			name:    "size within limit",
+			limits:  core.ExtractLimits{MaxBytes: 10},
+			entries: []tarEntry{file("a", "12345"), file("b", "12345")},
+		},
+		{
+			name:    "size over limit",
+			limits:  core.ExtractLimits{MaxBytes: 10},
+			entries: []tarEntry{file("a", "12345"), file("b", "123456")},
+			wantErr: true,
+		},
+		{
+			name:    "files within limit",
+			limits:  core.ExtractLimits{MaxFiles: 2},
+			entries: []tarEntry{file("a", "a"), symlink("b", "a")},
+		},
+		{
+			name:    "files over limit",
+			limits:  core.ExtractLimits{MaxFiles: 2},
+			entries: []tarEntry{file("a", "a"), file("b", "b"), symlink("c", "a")},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := extractArchive(t, NewExtractor().WithLimits(test.limits), buildTar(t, test.entries...))
+			if (err != nil) != test.wantErr {
+				t.Errorf("Extract error = %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package downloader
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// ArchiveFormat identifie le format d'une archive
+type ArchiveFormat string
+
+// Formats d'archive pris en charge
+const (
+	FormatUnknown ArchiveFormat = ""
+	FormatTar     ArchiveFormat = "tar"
+	FormatTarGz   ArchiveFormat = "tar.gz"
+	FormatTarXz   ArchiveFormat = "tar.xz"
+	FormatTarBz2  ArchiveFormat = "tar.bz2"
+	FormatTarZst  ArchiveFormat = "tar.zst"
+	FormatZip     ArchiveFormat = "zip"
+)
+
+// magicHeaderSize couvre l'en-tête "ustar" d'une archive tar non compressée
+const magicHeaderSize = 262
+
+var (
+	magicGzip  = []byte{0x1f, 0x8b}
+	magicXz    = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+	magicBzip2 = []byte{'B', 'Z', 'h'}
+	magicZstd  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	magicZip   = []byte{'P', 'K', 0x03, 0x04}
+	magicEmpty = []byte{'P', 'K', 0x05, 0x06}
+	magicTar   = []byte("ustar")
+)
+
+// detectFormatFromHeader identifie le format à partir des premiers octets
+func detectFormatFromHeader(header []byte) ArchiveFormat {
+	switch {
+	case bytes.HasPrefix(header, magicGzip):
+		return FormatTarGz
+	case bytes.HasPrefix(header, magicXz):
+		return FormatTarXz
+	case bytes.HasPrefix(header, magicBzip2):
+		return FormatTarBz2
+	case bytes.HasPrefix(header, magicZstd):
+		return FormatTarZst
+	case bytes.HasPrefix(header, magicZip), bytes.HasPrefix(header, magicEmpty):
+		return FormatZip
+	case len(header) >= magicHeaderSize && bytes.Equal(header[257:262], magicTar):
+		return FormatTar
+	}
+	return FormatUnknown
+}
+
+// detectFormatFromName identifie le format à partir de l'extension du fichier
+func detectFormatFromName(name string) ArchiveFormat {
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return FormatTarGz
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".txz"):
+		return FormatTarXz
+	case strings.HasSuffix(name, ".tar.bz2"), strings.HasSuffix(name, ".tbz2"):
+		return FormatTarBz2
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return FormatTarZst
+	case strings.HasSuffix(name, ".zip"):
+		return FormatZip
+	case strings.HasSuffix(name, ".tar"):
+		return FormatTar
+	}
+	return FormatUnknown
+}
+
+// DetectFormat identifie le format d'une archive par ses octets magiques,
+// l'extension n'étant utilisée qu'en dernier recours
+func DetectFormat(archivePath string) (ArchiveFormat, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return FormatUnknown, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	header := make([]byte, magicHeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return FormatUnknown, fmt.Errorf("failed to read archive header: %w", err)
+	}
+
+	if format := detectFormatFromHeader(header[:n]); format != FormatUnknown {
+		return format, nil
+	}
+	if format := detectFormatFromName(archivePath); format != FormatUnknown {
+		return format, nil
+	}
+	return FormatUnknown, fmt.Errorf("unsupported archive format")
+}
+
+// peekFormat identifie le format d'un flux sans consommer ses premiers octets
+func peekFormat(r *bufio.Reader, name string) (ArchiveFormat, error) {
+	header, err := r.Peek(magicHeaderSize)
+	if err != nil && err != io.EOF {
+		return FormatUnknown, fmt.Errorf("failed to read archive header: %w", err)
+	}
+
+	if format := detectFormatFromHeader(header); format != FormatUnknown {
+		return format, nil
+	}
+	if format := detectFormatFromName(name); format != FormatUnknown {
+		return format, nil
+	}
+	return FormatUnknown, fmt.Errorf("unsupported archive format")
+}
+
+// decompress retourne un lecteur du flux tar décompressé
+func decompress(format ArchiveFormat, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case FormatTar:
+		return io.NopCloser(r), nil
+	case FormatTarGz:
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		return gzr, nil
+	case FormatTarXz:
+		xzr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create xz reader: %w", err)
+		}
+		return io.NopCloser(xzr), nil
+	case FormatTarBz2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case FormatTarZst:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported archive format: %s", format)
+	}
+}
@@ -1,7 +1,11 @@
 package downloader
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"strigo/downloader/cache"
 	"strigo/downloader/core"
@@ -30,60 +34,168 @@ func NewManager() *Manager {
 	}
 }
 
-// DownloadAndExtract gère le processus complet de téléchargement et d'installation
-func (m *Manager) DownloadAndExtract(opts core.DownloadOptions) error {
+// DownloadAndExtract gère le processus complet de téléchargement et d'installation.
+// L'annulation du contexte interrompt le téléchargement et l'extraction.
+// Lorsque le cache n'est pas conservé, l'archive est extraite pendant son
+// téléchargement ; InstallPath doit alors être un répertoire jetable en cas d'échec,
+// l'intégrité n'étant connue qu'une fois le flux entièrement lu.
+func (m *Manager) DownloadAndExtract(ctx context.Context, opts core.DownloadOptions) (*core.DownloadResult, error) {
 	logging.LogDebug("🔍 Starting installation process for %s %s %s", opts.SDKType, opts.Distribution, opts.Version)
 
-	// Vérifier la taille du fichier
-	fileSize, err := m.network.GetFileSize(opts.DownloadURL)
+	client := m.network.WithCredentials(opts.Credentials).WithRetryPolicy(network.RetryPolicy{
+		Retries: opts.Retries,
+		Backoff: opts.RetryBackoff,
+	})
+
+	cachePath := m.cache.CachePath(opts.SDKType, opts.Distribution, opts.Version, opts.CacheDir)
+	cacheFile := filepath.Join(cachePath, filepath.Base(opts.DownloadURL))
+
+	// Vérifier la taille du fichier : une archive en cache est réutilisée sans
+	// interroger le serveur, ce qui permet de réinstaller hors ligne
+	var fileSize int64
+	info, statErr := os.Stat(cacheFile)
+	cached := statErr == nil
+	if cached {
+		fileSize = info.Size()
+	} else {
+		size, err := client.GetFileSize(ctx, opts.DownloadURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file size: %w", err)
+		}
+		fileSize = size
+	}
+	result := &core.DownloadResult{Size: fileSize}
+
+	// Une archive zip ne se lit pas en flux : inutile d'ouvrir un téléchargement pour le constater
+	streamable := detectFormatFromName(filepath.Base(opts.DownloadURL)) != FormatZip
+
+	streamed := false
+	if !opts.KeepCache && !cached && streamable {
+		sha256, err := m.streamAndExtract(ctx, client, opts, fileSize)
+		switch {
+		case err == nil:
+			streamed = true
+			result.SHA256 = sha256
+		case ctx.Err() == nil && (errors.Is(err, ErrStreamUnsupported) || errors.Is(err, network.ErrStreamInterrupted)):
+			// Un flux ne se reprend pas : on repart d'un répertoire vide avec un téléchargement en cache
+			logging.LogDebug("⚠️ Streamed extraction unavailable (%v), falling back to a cached download", err)
+			if err := os.RemoveAll(opts.InstallPath); err != nil {
+				return nil, fmt.Errorf("failed to reset installation directory: %w", err)
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	if !streamed {
+		sha256, err := m.downloadAndExtractFile(ctx, client, opts, fileSize, cachePath, cacheFile, cached)
+		if err != nil {
+			return nil, err
+		}
+		result.SHA256 = sha256
+	}
+
+	// Configurer les certificats si nécessaire
+	if opts.SDKType == "jdk" {
+		if err := m.certificates.SetupCertificates(opts.InstallPath, opts.CertConfig); err != nil {
+			logging.LogDebug("⚠️ Certificate setup failed: %v", err)
+			logging.LogInfo("ℹ️ JDK installation is complete but certificates were not configured")
+		}
+	}
+
+	logging.LogDebug("✅ Archive extracted to %s", opts.InstallPath)
+	return result, nil
+}
+
+// streamAndExtract extrait l'archive au fil du téléchargement, le flux passant
+// par le vérificateur d'empreintes avant d'atteindre le décompresseur ; retourne l'empreinte sha256
+func (m *Manager) streamAndExtract(ctx context.Context, client *network.Client, opts core.DownloadOptions, fileSize int64) (string, error) {
+	// Valider l'espace disponible : seule l'archive extraite occupe le disque
+	if err := m.validator.ValidateSpace(fileSize*core.ExtractionRatio, filepath.Dir(opts.InstallPath)); err != nil {
+		return "", fmt.Errorf("install directory space check failed: %w", err)
+	}
+
+	if err := m.validator.ValidateDirectories(opts.InstallPath); err != nil {
+		return "", fmt.Errorf("failed to prepare installation directory: %w", err)
+	}
+
+	stream, err := client.OpenStream(ctx, opts.DownloadURL)
 	if err != nil {
-		return fmt.Errorf("failed to get file size: %w", err)
+		return "", fmt.Errorf("download failed: %w", err)
 	}
+	defer stream.Close()
 
-	// Valider l'espace disponible
-	if err := m.validator.ValidateSpace(fileSize, opts.CacheDir); err != nil {
-		return fmt.Errorf("cache directory space check failed: %w", err)
+	verifier := core.NewVerifier(opts.Checksums)
+	reader := io.TeeReader(stream, verifier)
+
+	name := filepath.Base(opts.DownloadURL)
+	if err := m.extractorFor(opts).ExtractStream(ctx, reader, name, opts.InstallPath); err != nil {
+		return "", fmt.Errorf("extraction failed: %w", err)
 	}
-	if err := m.validator.ValidateSpace(fileSize, filepath.Dir(opts.InstallPath)); err != nil {
-		return fmt.Errorf("install directory space check failed: %w", err)
+
+	// Lire la fin du flux (bourrage tar, données finales) pour que l'empreinte couvre toute l'archive
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return "", fmt.Errorf("download failed: %w", err)
 	}
 
+	if err := verifier.Verify(); err != nil {
+		return "", fmt.Errorf("integrity check failed for %s: %w", name, err)
+	}
+	return verifier.SHA256(), nil
+}
+
+// downloadAndExtractFile télécharge l'archive dans le cache, la vérifie puis l'extrait ; retourne l'empreinte sha256
+func (m *Manager) downloadAndExtractFile(ctx context.Context, client *network.Client, opts core.DownloadOptions, fileSize int64, cachePath, cacheFile string, cached bool) (string, error) {
 	// Préparer le cache
-	cachePath, err := m.cache.PrepareCacheDirectory(opts.SDKType, opts.Distribution, opts.Version, opts.CacheDir)
-	if err != nil {
-		return fmt.Errorf("failed to prepare cache: %w", err)
+	if _, err := m.cache.PrepareCacheDirectory(opts.SDKType, opts.Distribution, opts.Version, opts.CacheDir); err != nil {
+		return "", fmt.Errorf("failed to prepare cache: %w", err)
+	}
+
+	// Valider l'espace disponible
+	if !cached {
+		if err := m.validator.ValidateSpace(fileSize, cachePath); err != nil {
+			return "", fmt.Errorf("cache directory space check failed: %w", err)
+		}
+	}
+	if err := m.validator.ValidateSpace(fileSize*core.ExtractionRatio, filepath.Dir(opts.InstallPath)); err != nil {
+		return "", fmt.Errorf("install directory space check failed: %w", err)
 	}
 
 	// Télécharger le fichier
-	cacheFile := filepath.Join(cachePath, filepath.Base(opts.DownloadURL))
-	if err := m.network.DownloadFile(opts.DownloadURL, cacheFile); err != nil {
-		return fmt.Errorf("download failed: %w", err)
+	if cached {
+		logging.LogDebug("📦 Using cached archive: %s", cacheFile)
+	} else if err := client.DownloadFile(ctx, opts.DownloadURL, cacheFile); err != nil {
+		return "", fmt.Errorf("download failed: %w", err)
+	}
+
+	// Vérifier l'intégrité de l'archive avant extraction
+	sha256, err := core.VerifyFile(cacheFile, opts.Checksums)
+	if err != nil {
+		logging.LogDebug("🗑️ Purging corrupted archive: %s", cacheFile)
+		if rmErr := os.Remove(cacheFile); rmErr != nil && !os.IsNotExist(rmErr) {
+			logging.LogDebug("⚠️ Failed to purge corrupted archive: %v", rmErr)
+		}
+		return "", fmt.Errorf("integrity check failed for %s: %w", filepath.Base(cacheFile), err)
 	}
 
 	// Valider et créer le répertoire d'installation
 	if err := m.validator.ValidateDirectories(opts.InstallPath); err != nil {
-		return fmt.Errorf("failed to prepare installation directory: %w", err)
+		return "", fmt.Errorf("failed to prepare installation directory: %w", err)
 	}
 
 	// Extraire l'archive
-	if err := m.extractor.Extract(cacheFile, opts.InstallPath); err != nil {
-		return fmt.Errorf("extraction failed: %w", err)
+	if err := m.extractorFor(opts).Extract(ctx, cacheFile, opts.InstallPath); err != nil {
+		return "", fmt.Errorf("extraction failed: %w", err)
 	}
 
 	// Nettoyer le cache si nécessaire
 	if err := m.cache.CleanupCache(cachePath, opts.KeepCache); err != nil {
 		logging.LogDebug("⚠️ Cache cleanup failed: %v", err)
 	}
+	return sha256, nil
+}
 
-	// Configurer les certificats si nécessaire
-	if opts.SDKType == "jdk" {
-		if err := m.certificates.SetupCertificates(opts.InstallPath, opts.CertConfig); err != nil {
-			logging.LogDebug("⚠️ Certificate setup failed: %v", err)
-			logging.LogInfo("ℹ️ JDK installation is complete but certificates were not configured")
-		}
-	}
-
-	logging.LogInfo("✅ Successfully installed %s %s version %s", opts.SDKType, opts.Distribution, opts.Version)
-	logging.LogInfo("📂 Installation path: %s", opts.InstallPath)
-	return nil
+// extractorFor configure l'extracteur selon les options d'installation
+func (m *Manager) extractorFor(opts core.DownloadOptions) *Extractor {
+	return m.extractor.WithLimits(opts.ExtractLimits).WithStripComponents(opts.StripComponents)
 }
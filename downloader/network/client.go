@@ -1,66 +1,249 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"strconv"
+	"strigo/config"
 	"strigo/logging"
+	"time"
 )
 
+// RetryPolicy définit le nombre de tentatives et le délai initial entre deux tentatives
+type RetryPolicy struct {
+	Retries int
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy est utilisée lorsque rien n'est configuré
+var DefaultRetryPolicy = RetryPolicy{Retries: 3, Backoff: time.Second}
+
+// httpClient limite l'attente de connexion et d'en-têtes sans borner la durée du téléchargement
+var httpClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 60 * time.Second,
+	},
+}
+
 // Client gère les opérations réseau
-type Client struct{}
+type Client struct {
+	credentials *config.Credentials
+	retry       RetryPolicy
+}
 
 // NewClient crée une nouvelle instance de Client
 func NewClient() *Client {
-	return &Client{}
+	return &Client{retry: DefaultRetryPolicy}
 }
 
-// GetFileSize récupère la taille d'un fichier distant
-func (c *Client) GetFileSize(url string) (int64, error) {
-	resp, err := http.Head(url)
+// WithCredentials retourne une copie du client qui authentifie ses requêtes
+func (c *Client) WithCredentials(credentials *config.Credentials) *Client {
+	clone := *c
+	clone.credentials = credentials
+	return &clone
+}
+
+// WithRetryPolicy retourne une copie du client avec une autre politique de tentatives
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	clone := *c
+	clone.retry = policy
+	return &clone
+}
+
+// do exécute une requête en y ajoutant l'authentification éventuelle
+func (c *Client) do(ctx context.Context, method, url string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get file size: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	c.credentials.Apply(req)
+	return httpClient.Do(req)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("server returned non-OK status: %s", resp.Status)
+// GetFileSize récupère la taille d'un fichier distant, avec la même politique
+// de tentatives que les téléchargements
+func (c *Client) GetFileSize(ctx context.Context, url string) (int64, error) {
+	if path, ok := localPath(url); ok {
+		return localFileSize(path)
 	}
 
-	size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	var size int64
+	err := c.withRetries(ctx, nil, func() error {
+		resp, err := c.do(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return fmt.Errorf("network request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+		case isRetryableStatus(resp.StatusCode):
+			return fmt.Errorf("server returned non-OK status: %s", resp.Status)
+		default:
+			return &permanentError{fmt.Errorf("server returned non-OK status: %s", resp.Status)}
+		}
+
+		size, err = strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+		if err != nil {
+			return &permanentError{fmt.Errorf("invalid Content-Length: %w", err)}
+		}
+		return nil
+	})
 	if err != nil {
-		return 0, fmt.Errorf("invalid Content-Length: %w", err)
+		return 0, err
 	}
-
 	return size, nil
 }
 
-// DownloadFile télécharge un fichier depuis une URL
-func (c *Client) DownloadFile(url, filepath string) error {
-	logging.LogDebug("📡 Initiating network request to %s", url)
-	resp, err := http.Get(url)
+// DownloadFile télécharge un fichier depuis une URL.
+// Le contenu est écrit dans un fichier .part, repris via des requêtes Range
+// après une coupure, puis renommé atomiquement une fois complet.
+func (c *Client) DownloadFile(ctx context.Context, url, filepath string) error {
+	if path, ok := localPath(url); ok {
+		return copyLocalFile(ctx, path, filepath)
+	}
+
+	partPath := filepath + ".part"
+	progress := newProgressReporter(url)
+
+	err := c.withRetries(ctx, progress, func() error {
+		return c.downloadPart(ctx, url, partPath, progress)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(partPath, filepath); err != nil {
+		return fmt.Errorf("failed to finalize download: %w", err)
+	}
+	return nil
+}
+
+// withRetries exécute une tentative et la répète avec un délai croissant
+// tant que l'erreur est transitoire et que le nombre de tentatives le permet ;
+// progress vaut nil pour une requête sans corps à afficher
+func (c *Client) withRetries(ctx context.Context, progress *progressReporter, attempt func() error) error {
+	backoff := c.retry.Backoff
+
+	for i := 0; ; i++ {
+		err := attempt()
+		if err == nil {
+			return nil
+		}
+
+		if progress != nil {
+			progress.interrupt()
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) || ctx.Err() != nil || i >= c.retry.Retries {
+			return err
+		}
+
+		logging.LogInfo("⚠️ Download interrupted (%v), retrying in %s (%d/%d)", err, backoff, i+1, c.retry.Retries)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// permanentError signale une erreur pour laquelle une nouvelle tentative est inutile
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// downloadPart télécharge ou reprend le fichier .part
+func (c *Client) downloadPart(ctx context.Context, url, partPath string, progress *progressReporter) error {
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+
+	headers := map[string]string{}
+	if offset > 0 {
+		headers["Range"] = fmt.Sprintf("bytes=%d-", offset)
+		logging.LogDebug("📡 Resuming download of %s from byte %d", url, offset)
+	} else {
+		logging.LogDebug("📡 Initiating network request to %s", url)
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, url, headers)
 	if err != nil {
 		return fmt.Errorf("network request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	flags := os.O_CREATE | os.O_WRONLY
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+	case resp.StatusCode == http.StatusOK:
+		if offset > 0 {
+			logging.LogDebug("⚠️ Server does not support range requests, restarting download")
+		}
+		offset = 0
+		flags |= os.O_TRUNC
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// La partie locale est invalide (fichier distant modifié ou déjà complet) : on repart de zéro
+		if err := os.Remove(partPath); err != nil && !os.IsNotExist(err) {
+			return &permanentError{fmt.Errorf("failed to reset partial download: %w", err)}
+		}
+		return fmt.Errorf("server rejected range request: %s", resp.Status)
+	case isRetryableStatus(resp.StatusCode):
 		return fmt.Errorf("server returned non-OK status: %s", resp.Status)
+	default:
+		return &permanentError{fmt.Errorf("server returned non-OK status: %s", resp.Status)}
 	}
 
-	out, err := os.Create(filepath)
+	out, err := os.OpenFile(partPath, flags, 0644)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return &permanentError{fmt.Errorf("failed to create output file: %w", err)}
 	}
 	defer out.Close()
 
-	written, err := io.Copy(out, resp.Body)
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	progress.begin(offset, total)
+
+	written, err := io.Copy(out, io.TeeReader(resp.Body, progress))
 	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+		return fmt.Errorf("failed to write file after %d bytes: %w", offset+written, err)
+	}
+
+	if resp.ContentLength >= 0 && written != resp.ContentLength {
+		return fmt.Errorf("incomplete download: received %d of %d bytes", written, resp.ContentLength)
 	}
+	progress.finish()
 
-	logging.LogDebug("✅ Download completed. Wrote %d bytes", written)
+	logging.LogDebug("✅ Download completed. Wrote %d bytes", offset+written)
 	return nil
 }
+
+// isRetryableStatus indique si un statut HTTP correspond à une erreur transitoire
+func isRetryableStatus(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests || status == http.StatusRequestTimeout
+}
@@ -0,0 +1,74 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strigo/downloader/core"
+	"strigo/logging"
+)
+
+// localPath retourne le chemin local d'une URL file://
+func localPath(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	return filepath.FromSlash(u.Path), true
+}
+
+// localFileSize récupère la taille d'un fichier local
+func localFileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get file size: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// copyLocalFile copie un fichier local vers le cache au lieu de le télécharger.
+// La copie passe par un fichier .part renommé une fois complet, pour ne jamais
+// laisser une archive tronquée dans le cache.
+func copyLocalFile(ctx context.Context, src, dst string) error {
+	logging.LogDebug("📁 Copying local file %s", src)
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer in.Close()
+
+	partPath := dst + ".part"
+	out, err := os.Create(partPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	progress := newProgressReporter(src)
+	if info, err := in.Stat(); err == nil {
+		progress.begin(0, info.Size())
+	}
+
+	written, err := io.Copy(out, io.TeeReader(core.NewContextReader(ctx, in), progress))
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(partPath)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(partPath, dst); err != nil {
+		os.Remove(partPath)
+		return fmt.Errorf("failed to finalize copy: %w", err)
+	}
+
+	progress.finish()
+	logging.LogDebug("✅ Copy completed. Wrote %d bytes", written)
+	return nil
+}
@@ -0,0 +1,157 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strigo/logging"
+	"time"
+)
+
+const (
+	progressRenderInterval = 200 * time.Millisecond
+	progressEventInterval  = time.Second
+)
+
+// ProgressEvent représente l'état d'un téléchargement, émis en JSON avec --json-logs
+type ProgressEvent struct {
+	File           string  `json:"file"`
+	BytesDone      int64   `json:"bytes_done"`
+	BytesTotal     int64   `json:"bytes_total"`
+	Percent        float64 `json:"percent"`
+	BytesPerSecond float64 `json:"bytes_per_second"`
+	ETASeconds     float64 `json:"eta_seconds"`
+	Done           bool    `json:"done"`
+}
+
+// progressReporter suit l'avancement d'un téléchargement et l'affiche
+// sur un terminal ou sous forme d'événements JSON
+type progressReporter struct {
+	file       string
+	total      int64
+	done       int64
+	resumed    int64
+	start      time.Time
+	lastReport time.Time
+	tty        bool
+	json       bool
+}
+
+// newProgressReporter crée un rapporteur pour un fichier ; total vaut -1 si la taille est inconnue
+func newProgressReporter(url string) *progressReporter {
+	return &progressReporter{
+		file:  path.Base(url),
+		total: -1,
+		start: time.Now(),
+		tty:   isTerminal(os.Stderr),
+		json:  logging.IsJSON(),
+	}
+}
+
+// isTerminal indique si le fichier est un terminal interactif
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// enabled indique si l'avancement doit être rapporté
+func (p *progressReporter) enabled() bool {
+	return p.tty || p.json
+}
+
+// begin initialise la position courante, par exemple lors de la reprise d'un fichier .part
+func (p *progressReporter) begin(offset, total int64) {
+	p.done = offset
+	p.resumed = offset
+	p.total = total
+	p.start = time.Now()
+}
+
+// Write implémente io.Writer pour être utilisé avec io.TeeReader
+func (p *progressReporter) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+
+	interval := progressEventInterval
+	if p.tty {
+		interval = progressRenderInterval
+	}
+	if p.enabled() && time.Since(p.lastReport) >= interval {
+		p.report(false)
+	}
+	return len(b), nil
+}
+
+// finish émet le dernier état du téléchargement
+func (p *progressReporter) finish() {
+	if p.enabled() {
+		p.report(true)
+	}
+}
+
+// interrupt termine la ligne de progression en cours avant un message d'erreur
+func (p *progressReporter) interrupt() {
+	if p.tty && !p.lastReport.IsZero() {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func (p *progressReporter) report(done bool) {
+	p.lastReport = time.Now()
+	event := p.snapshot(done)
+
+	if p.json {
+		logging.LogProgress("Downloading %s", event, p.file)
+		return
+	}
+
+	line := fmt.Sprintf("⬇️  %s  %s", p.file, formatBytes(event.BytesDone))
+	if event.BytesTotal > 0 {
+		line += fmt.Sprintf(" / %s (%.0f%%)", formatBytes(event.BytesTotal), event.Percent)
+	}
+	line += fmt.Sprintf("  %s/s", formatBytes(int64(event.BytesPerSecond)))
+	if event.BytesTotal > 0 && !done {
+		line += fmt.Sprintf("  ETA %s", (time.Duration(event.ETASeconds) * time.Second).String())
+	}
+
+	fmt.Fprintf(os.Stderr, "\r\033[K%s", line)
+	if done {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+func (p *progressReporter) snapshot(done bool) ProgressEvent {
+	event := ProgressEvent{
+		File:       p.file,
+		BytesDone:  p.done,
+		BytesTotal: p.total,
+		Done:       done,
+	}
+
+	if elapsed := time.Since(p.start).Seconds(); elapsed > 0 {
+		event.BytesPerSecond = float64(p.done-p.resumed) / elapsed
+	}
+	if p.total > 0 {
+		event.Percent = float64(p.done) * 100 / float64(p.total)
+		if event.BytesPerSecond > 0 {
+			event.ETASeconds = float64(p.total-p.done) / event.BytesPerSecond
+		}
+	}
+	return event
+}
+
+// formatBytes formate une taille en unités binaires lisibles
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,96 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strigo/downloader/core"
+	"strigo/logging"
+)
+
+// ErrStreamInterrupted signale une coupure du flux en cours de lecture.
+// Contrairement à DownloadFile, un flux ne peut pas être repris.
+var ErrStreamInterrupted = errors.New("download stream interrupted")
+
+// OpenStream ouvre le contenu d'une URL en lecture séquentielle, sans l'écrire sur disque.
+// Les erreurs transitoires à l'ouverture sont retentées selon la politique du client.
+func (c *Client) OpenStream(ctx context.Context, url string) (io.ReadCloser, error) {
+	progress := newProgressReporter(url)
+
+	if path, ok := localPath(url); ok {
+		logging.LogDebug("📁 Streaming local file %s", path)
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open source file: %w", err)
+		}
+		if info, err := file.Stat(); err == nil {
+			progress.begin(0, info.Size())
+		}
+		return &streamReader{reader: core.NewContextReader(ctx, file), closer: file, progress: progress}, nil
+	}
+
+	var stream io.ReadCloser
+	err := c.withRetries(ctx, progress, func() error {
+		logging.LogDebug("📡 Opening download stream to %s", url)
+		resp, err := c.do(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("network request failed: %w", err)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+		case isRetryableStatus(resp.StatusCode):
+			resp.Body.Close()
+			return fmt.Errorf("server returned non-OK status: %s", resp.Status)
+		default:
+			resp.Body.Close()
+			return &permanentError{fmt.Errorf("server returned non-OK status: %s", resp.Status)}
+		}
+
+		total := int64(-1)
+		if resp.ContentLength >= 0 {
+			total = resp.ContentLength
+		}
+		progress.begin(0, total)
+		stream = &streamReader{reader: resp.Body, closer: resp.Body, progress: progress}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// streamReader rapporte l'avancement de la lecture et marque les coupures réseau
+type streamReader struct {
+	reader   io.Reader
+	closer   io.Closer
+	progress *progressReporter
+}
+
+// Read implémente io.Reader
+func (s *streamReader) Read(p []byte) (int, error) {
+	n, err := s.reader.Read(p)
+	if n > 0 {
+		s.progress.Write(p[:n])
+	}
+	switch {
+	case err == io.EOF:
+		s.progress.finish()
+	case err != nil:
+		s.progress.interrupt()
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return n, err
+		}
+		return n, fmt.Errorf("%w: %v", ErrStreamInterrupted, err)
+	}
+	return n, err
+}
+
+// Close implémente io.Closer
+func (s *streamReader) Close() error {
+	return s.closer.Close()
+}
@@ -3,6 +3,7 @@ module strigo
 go 1.23.4
 
 require (
+	github.com/klauspost/compress v1.18.0
 	github.com/pelletier/go-toml v1.9.5
 	github.com/spf13/cobra v1.8.1
 )
@@ -0,0 +1,107 @@
+package installation
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// requiredFiles lists, per SDK type, the files an installation cannot work without,
+// relative to the SDK home; executables must keep their execute permission
+var requiredFiles = map[string][]requiredFile{
+	"jdk": {
+		{path: "bin/java", executable: true},
+		{path: "release"},
+	},
+	"node": {
+		{path: "bin/node", executable: true},
+	},
+}
+
+type requiredFile struct {
+	path       string
+	executable bool
+}
+
+// Diagnose lists the problems of an installation: missing SDK home or required
+// files, broken JDK certificate link and files differing from the install manifest.
+// jdkSecurityPath is the location of the certificate link inside a JDK home.
+func Diagnose(installPath, sdkType, jdkSecurityPath string) ([]string, error) {
+	home, err := Home(installPath, sdkType)
+	if err != nil {
+		return []string{fmt.Sprintf("SDK home not found: %v", err)}, nil
+	}
+
+	// Paths are reported relative to the installation, like in the manifest
+	var problems []string
+	reported := make(map[string]bool)
+	for _, required := range requiredFiles[sdkType] {
+		path := filepath.Join(home, required.path)
+		relative := relativePath(installPath, path)
+
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("missing file %s", relative))
+			reported[relative] = true
+		case required.executable && info.Mode().Perm()&0111 == 0:
+			problems = append(problems, fmt.Sprintf("%s is not executable", relative))
+			reported[relative] = true
+		}
+	}
+
+	if sdkType == "jdk" && jdkSecurityPath != "" {
+		linkPath := filepath.Join(home, jdkSecurityPath)
+		if problem := checkCertificateLink(linkPath); problem != "" {
+			problems = append(problems, problem)
+			reported[relativePath(installPath, linkPath)] = true
+		}
+	}
+
+	drift, err := Verify(installPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// Installations made before manifests were recorded can only be checked for required files
+	case err != nil:
+		return nil, err
+	default:
+		for _, path := range drift.Missing {
+			if !reported[path] {
+				problems = append(problems, fmt.Sprintf("missing file %s", path))
+			}
+		}
+		for _, path := range drift.Modified {
+			if !reported[path] {
+				problems = append(problems, fmt.Sprintf("modified file %s", path))
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// checkCertificateLink checks the link created at install time from the JDK truststore to the system certificates
+func checkCertificateLink(linkPath string) string {
+	info, err := os.Lstat(linkPath)
+	if err != nil {
+		return fmt.Sprintf("missing certificate link %s", linkPath)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		return ""
+	}
+	if _, err := os.Stat(linkPath); err != nil {
+		target, _ := os.Readlink(linkPath)
+		return fmt.Sprintf("broken certificate link %s -> %s", linkPath, target)
+	}
+	return ""
+}
+
+// relativePath returns path relative to the installation, slash-separated like manifest entries
+func relativePath(installPath, path string) string {
+	relative, err := filepath.Rel(installPath, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(relative)
+}
@@ -0,0 +1,134 @@
+package installation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strigo/logging"
+	"strings"
+)
+
+// MetadataDirName is the directory, inside an installation, where strigo keeps its records
+const MetadataDirName = ".strigo"
+
+// homeRecordName is the file recording the SDK home, relative to the installation directory
+const homeRecordName = "home"
+
+// maxHomeDepth bounds how deep the SDK home is searched (macOS bundles use <name>.jdk/Contents/Home)
+const maxHomeDepth = 3
+
+// homeMarkers lists the files identifying the home directory of each SDK type
+var homeMarkers = map[string][]string{
+	"jdk":  {"release", "bin/java", "bin/java.exe"},
+	"node": {"bin/node", "node.exe"},
+}
+
+// MetadataDir returns the metadata directory of an installation
+func MetadataDir(installPath string) string {
+	return filepath.Join(installPath, MetadataDirName)
+}
+
+// DetectHome searches an extracted installation for the SDK home directory,
+// from the shallowest level down, using the markers of the SDK type
+func DetectHome(installPath, sdkType string) (string, error) {
+	markers, known := homeMarkers[sdkType]
+	if !known {
+		markers = []string{"bin"}
+	}
+
+	level := []string{installPath}
+	for depth := 0; depth <= maxHomeDepth && len(level) > 0; depth++ {
+		var found, next []string
+		for _, dir := range level {
+			if hasMarker(dir, markers) {
+				found = append(found, dir)
+				continue
+			}
+			next = append(next, subdirectories(dir)...)
+		}
+
+		switch len(found) {
+		case 0:
+			level = next
+		case 1:
+			logging.LogDebug("🏠 Detected %s home: %s", sdkType, found[0])
+			return found[0], nil
+		default:
+			return "", fmt.Errorf("multiple %s homes found in %s: %s", strings.ToUpper(sdkType), installPath, strings.Join(found, ", "))
+		}
+	}
+
+	// Previous behaviour for SDK types without markers: a single top-level directory
+	if !known {
+		if dirs := subdirectories(installPath); len(dirs) == 1 {
+			return dirs[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find %s home in %s", strings.ToUpper(sdkType), installPath)
+}
+
+// hasMarker reports whether one of the markers exists in dir
+func hasMarker(dir string, markers []string) bool {
+	for _, marker := range markers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// subdirectories lists the visible subdirectories of dir, sorted by name
+func subdirectories(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			dirs = append(dirs, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// RecordHome stores the SDK home of an installation, relative to it so the
+// installation can be moved (e.g. from its staging directory) without rewriting it
+func RecordHome(installPath, home string) error {
+	relative, err := filepath.Rel(installPath, home)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(os.PathSeparator)) {
+		return fmt.Errorf("SDK home %s is outside of %s", home, installPath)
+	}
+
+	if err := os.MkdirAll(MetadataDir(installPath), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	record := filepath.Join(MetadataDir(installPath), homeRecordName)
+	if err := os.WriteFile(record, []byte(filepath.ToSlash(relative)+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to record SDK home: %w", err)
+	}
+	return nil
+}
+
+// Home returns the SDK home of an installation from its record, detecting it
+// for installations made before homes were recorded
+func Home(installPath, sdkType string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(MetadataDir(installPath), homeRecordName))
+	if os.IsNotExist(err) {
+		logging.LogDebug("🔍 No recorded home in %s, detecting it", installPath)
+		return DetectHome(installPath, sdkType)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read SDK home record: %w", err)
+	}
+
+	home := filepath.Join(installPath, filepath.FromSlash(strings.TrimSpace(string(content))))
+	if _, err := os.Stat(home); err != nil {
+		return "", fmt.Errorf("recorded SDK home %s is missing: %w", home, err)
+	}
+	return home, nil
+}
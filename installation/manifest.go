@@ -0,0 +1,178 @@
+package installation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// manifestFileName is the per-file hash manifest inside the metadata directory
+const manifestFileName = "manifest.json"
+
+// ManifestEntry describes one file of an installation: a regular file by its
+// hash, size and permissions, a symbolic link by its target
+type ManifestEntry struct {
+	SHA256 string      `json:"sha256,omitempty"`
+	Size   int64       `json:"size,omitempty"`
+	Mode   fs.FileMode `json:"mode,omitempty"`
+	Link   string      `json:"link,omitempty"`
+}
+
+// Manifest lists every file of an installation, keyed by slash-separated relative path
+type Manifest struct {
+	Files map[string]ManifestEntry `json:"files"`
+}
+
+// Drift is the difference between an installation and its manifest
+type Drift struct {
+	Modified []string `json:"modified"`
+	Missing  []string `json:"missing"`
+	Extra    []string `json:"extra"`
+}
+
+// IsClean reports whether the installation matches its manifest
+func (d *Drift) IsClean() bool {
+	return len(d.Modified) == 0 && len(d.Missing) == 0 && len(d.Extra) == 0
+}
+
+// ManifestPath returns the manifest file of an installation
+func ManifestPath(installPath string) string {
+	return filepath.Join(MetadataDir(installPath), manifestFileName)
+}
+
+// BuildManifest hashes every file of an installation, except its metadata directory
+func BuildManifest(installPath string) (*Manifest, error) {
+	manifest := &Manifest{Files: make(map[string]ManifestEntry)}
+
+	err := filepath.WalkDir(installPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == MetadataDir(installPath) {
+			return filepath.SkipDir
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(installPath, path)
+		if err != nil {
+			return err
+		}
+		manifestEntry, err := describe(path, entry)
+		if err != nil {
+			return err
+		}
+		manifest.Files[filepath.ToSlash(relative)] = manifestEntry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build manifest of %s: %w", installPath, err)
+	}
+	return manifest, nil
+}
+
+// describe builds the manifest entry of a file
+func describe(path string, entry fs.DirEntry) (ManifestEntry, error) {
+	info, err := entry.Info()
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+
+	if info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return ManifestEntry{}, err
+		}
+		return ManifestEntry{Link: target}, nil
+	}
+	if !info.Mode().IsRegular() {
+		return ManifestEntry{Mode: info.Mode()}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return ManifestEntry{}, err
+	}
+	return ManifestEntry{
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+		Size:   info.Size(),
+		Mode:   info.Mode().Perm(),
+	}, nil
+}
+
+// WriteManifest stores the manifest of an installation
+func WriteManifest(installPath string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(MetadataDir(installPath), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := os.WriteFile(ManifestPath(installPath), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadManifest loads the manifest of an installation. The returned error wraps
+// os.ErrNotExist for installations made before manifests were recorded.
+func ReadManifest(installPath string) (*Manifest, error) {
+	data, err := os.ReadFile(ManifestPath(installPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", ManifestPath(installPath), err)
+	}
+	return &manifest, nil
+}
+
+// Verify compares an installation with the manifest captured at install time
+func Verify(installPath string) (*Drift, error) {
+	expected, err := ReadManifest(installPath)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := BuildManifest(installPath)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := &Drift{Modified: []string{}, Missing: []string{}, Extra: []string{}}
+	for path, entry := range expected.Files {
+		current, found := actual.Files[path]
+		switch {
+		case !found:
+			drift.Missing = append(drift.Missing, path)
+		case current != entry:
+			drift.Modified = append(drift.Modified, path)
+		}
+	}
+	for path := range actual.Files {
+		if _, found := expected.Files[path]; !found {
+			drift.Extra = append(drift.Extra, path)
+		}
+	}
+
+	sort.Strings(drift.Modified)
+	sort.Strings(drift.Missing)
+	sort.Strings(drift.Extra)
+	return drift, nil
+}
@@ -0,0 +1,65 @@
+package installation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// receiptFileName is the receipt file inside the metadata directory
+const receiptFileName = "receipt.json"
+
+// Receipt records where an installed SDK came from
+type Receipt struct {
+	SDKType       string    `json:"sdk_type"`
+	Distribution  string    `json:"distribution"`
+	Version       string    `json:"version"`
+	Registry      string    `json:"registry"`
+	Repository    string    `json:"repository"`
+	DownloadURL   string    `json:"download_url"`
+	SHA256        string    `json:"sha256,omitempty"`
+	ArchiveSize   int64     `json:"archive_size"`
+	InstalledAt   time.Time `json:"installed_at"`
+	Home          string    `json:"home"` // Relative to the installation directory
+	OS            string    `json:"os,omitempty"`
+	Arch          string    `json:"arch,omitempty"`
+	StrigoVersion string    `json:"strigo_version"`
+}
+
+// ReceiptPath returns the receipt file of an installation
+func ReceiptPath(installPath string) string {
+	return filepath.Join(MetadataDir(installPath), receiptFileName)
+}
+
+// WriteReceipt stores the receipt of an installation
+func WriteReceipt(installPath string, receipt Receipt) error {
+	data, err := json.MarshalIndent(receipt, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode receipt: %w", err)
+	}
+
+	if err := os.MkdirAll(MetadataDir(installPath), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := os.WriteFile(ReceiptPath(installPath), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write receipt: %w", err)
+	}
+	return nil
+}
+
+// ReadReceipt loads the receipt of an installation. The returned error wraps
+// os.ErrNotExist for installations made before receipts were recorded.
+func ReadReceipt(installPath string) (*Receipt, error) {
+	data, err := os.ReadFile(ReceiptPath(installPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read receipt: %w", err)
+	}
+
+	var receipt Receipt
+	if err := json.Unmarshal(data, &receipt); err != nil {
+		return nil, fmt.Errorf("failed to parse receipt %s: %w", ReceiptPath(installPath), err)
+	}
+	return &receipt, nil
+}
@@ -0,0 +1,208 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+// strigoPin is one SDK table of a .strigo.toml file:
+//
+//	[jdk]
+//	distribution = "temurin"
+//	version = "21"
+type strigoPin struct {
+	Distribution string `toml:"distribution"`
+	Version      string `toml:"version"`
+}
+
+// sdkmanVendors maps SDKMAN! vendor suffixes (21.0.2-tem) to distribution names
+var sdkmanVendors = map[string]string{
+	"tem":     "temurin",
+	"amzn":    "corretto",
+	"zulu":    "zulu",
+	"librca":  "liberica",
+	"ms":      "microsoft",
+	"sem":     "semeru",
+	"graal":   "graalvm",
+	"graalce": "graalvm",
+	"oracle":  "oracle",
+	"open":    "openjdk",
+	"sapmchn": "sapmachine",
+}
+
+// nodeCodenames maps Node.js LTS codenames (lts/iron) to their major version
+var nodeCodenames = map[string]string{
+	"argon":    "4",
+	"boron":    "6",
+	"carbon":   "8",
+	"dubnium":  "10",
+	"erbium":   "12",
+	"fermium":  "14",
+	"gallium":  "16",
+	"hydrogen": "18",
+	"iron":     "20",
+	"jod":      "22",
+	"krypton":  "24",
+}
+
+var (
+	// distributionPattern splits a vendor-prefixed version (temurin-21.0.2, corretto64-17)
+	distributionPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9.]*?)(?:64)?-(\d.*)$`)
+	// asdfBuildSuffix matches the trailing components asdf-java appends to builds (+13.0.LTS)
+	asdfBuildSuffix = regexp.MustCompile(`(\+\d+)(?:\.\d+)*(?:\.LTS)?$`)
+	// jenvLegacyPattern matches the 1.8 spelling of Java 8
+	jenvLegacyPattern = regexp.MustCompile(`^1\.([1-8])$`)
+)
+
+// parseStrigoFile reads a .strigo.toml file, one table per SDK type
+func parseStrigoFile(data []byte) ([]Pin, error) {
+	var file map[string]strigoPin
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	var pins []Pin
+	for sdkType, pin := range file {
+		if pin.Version == "" {
+			return nil, fmt.Errorf("no version set for %s", sdkType)
+		}
+		pins = append(pins, Pin{SDKType: sdkType, Distribution: pin.Distribution, Version: pin.Version})
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		return pins[i].SDKType < pins[j].SDKType
+	})
+	return pins, nil
+}
+
+// parseToolVersions reads an asdf .tool-versions file ("java temurin-21.0.2+13.0.LTS", "nodejs 22.1.0").
+// SDKMAN! identifiers ("java 21.0.2-tem") are accepted for the known vendors.
+func parseToolVersions(data []byte) ([]Pin, error) {
+	var pins []Pin
+	for _, line := range lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[1] == "system" {
+			continue
+		}
+		// Only the first version is used, the following ones are asdf fallbacks
+		version := strings.TrimPrefix(fields[1], "latest:")
+
+		switch fields[0] {
+		case "java":
+			if i := strings.LastIndex(version, "-"); i > 0 {
+				if _, known := sdkmanVendors[version[i+1:]]; known {
+					pins = append(pins, sdkmanPin(version))
+					continue
+				}
+			}
+			pin := javaPin(version)
+			pin.Version = asdfBuildSuffix.ReplaceAllString(pin.Version, "$1")
+			pins = append(pins, pin)
+		case "nodejs", "node":
+			pins = append(pins, nodePin(version))
+		}
+	}
+	return pins, nil
+}
+
+// parseSdkmanrc reads an SDKMAN! .sdkmanrc file ("java=21.0.2-tem")
+func parseSdkmanrc(data []byte) ([]Pin, error) {
+	var pins []Pin
+	for _, line := range lines(data) {
+		candidate, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		if strings.TrimSpace(candidate) != "java" {
+			continue
+		}
+
+		pins = append(pins, sdkmanPin(strings.TrimSpace(value)))
+	}
+	return pins, nil
+}
+
+// parseJavaVersion reads a jenv .java-version file ("17", "temurin64-17.0.9")
+func parseJavaVersion(data []byte) ([]Pin, error) {
+	version := firstLine(data)
+	if version == "" {
+		return nil, nil
+	}
+	return []Pin{javaPin(version)}, nil
+}
+
+// parseNodeVersion reads a .nvmrc or .node-version file ("v22.1.0", "lts/iron", "node")
+func parseNodeVersion(data []byte) ([]Pin, error) {
+	version := firstLine(data)
+	if version == "" {
+		return nil, nil
+	}
+	return []Pin{nodePin(version)}, nil
+}
+
+// sdkmanPin builds a JDK pin from an SDKMAN! identifier ("21.0.2-tem")
+func sdkmanPin(identifier string) Pin {
+	pin := Pin{SDKType: "jdk", Version: identifier}
+	if i := strings.LastIndex(identifier, "-"); i > 0 {
+		vendor := identifier[i+1:]
+		pin.Version = identifier[:i]
+		pin.Distribution = vendor
+		if name, known := sdkmanVendors[vendor]; known {
+			pin.Distribution = name
+		}
+	}
+	return pin
+}
+
+// javaPin builds a JDK pin from an optionally vendor-prefixed version
+func javaPin(version string) Pin {
+	pin := Pin{SDKType: "jdk", Version: version}
+	if m := distributionPattern.FindStringSubmatch(version); m != nil {
+		pin.Distribution = strings.ToLower(m[1])
+		pin.Version = m[2]
+	}
+	pin.Version = jenvLegacyPattern.ReplaceAllString(pin.Version, "$1")
+	return pin
+}
+
+// nodePin builds a Node.js pin, translating the nvm aliases
+func nodePin(version string) Pin {
+	version = strings.TrimPrefix(version, "v")
+	switch {
+	case version == "node" || version == "stable":
+		version = "latest"
+	case version == "lts/*" || version == "lts":
+		version = "lts"
+	case strings.HasPrefix(version, "lts/"):
+		if major, known := nodeCodenames[strings.ToLower(strings.TrimPrefix(version, "lts/"))]; known {
+			version = major
+		}
+	}
+	return Pin{SDKType: "node", Version: version}
+}
+
+// lines returns the non-empty lines of a file, without comments
+func lines(data []byte) []string {
+	var result []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// firstLine returns the first non-empty line of a file, without comments
+func firstLine(data []byte) string {
+	if all := lines(data); len(all) > 0 {
+		return all[0]
+	}
+	return ""
+}
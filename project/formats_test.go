@@ -0,0 +1,159 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParsers(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func([]byte) ([]Pin, error)
+		data  string
+		want  []Pin
+	}{
+		{
+			name:  "strigo file",
+			parse: parseStrigoFile,
+			data:  "# project SDKs\n\n[node]\nversion = \"lts\"\n\n[jdk]\ndistribution = \"temurin\"\nversion = \"21\"\n",
+			want: []Pin{
+				{SDKType: "jdk", Distribution: "temurin", Version: "21"},
+				{SDKType: "node", Version: "lts"},
+			},
+		},
+		{
+			name:  "strigo file with CRLF",
+			parse: parseStrigoFile,
+			data:  "[jdk]\r\ndistribution = \"temurin\"\r\nversion = \">=17 <22\"\r\n",
+			want:  []Pin{{SDKType: "jdk", Distribution: "temurin", Version: ">=17 <22"}},
+		},
+		{
+			name:  "tool-versions",
+			parse: parseToolVersions,
+			data:  "# asdf\njava temurin-21.0.2+13.0.LTS 17.0.9\n\nnodejs 22.1.0 # current\npython 3.12.1\n",
+			want: []Pin{
+				{SDKType: "jdk", Distribution: "temurin", Version: "21.0.2+13"},
+				{SDKType: "node", Version: "22.1.0"},
+			},
+		},
+		{
+			name:  "tool-versions with SDKMAN! identifier",
+			parse: parseToolVersions,
+			data:  "java 17.0.2-tem\r\nnodejs system\r\n",
+			want:  []Pin{{SDKType: "jdk", Distribution: "temurin", Version: "17.0.2"}},
+		},
+		{
+			name:  "sdkmanrc",
+			parse: parseSdkmanrc,
+			data:  "# Enable auto-env through the sdkman_auto_env config\n\njava=17.0.2-tem\nmaven=3.9.6\n",
+			want:  []Pin{{SDKType: "jdk", Distribution: "temurin", Version: "17.0.2"}},
+		},
+		{
+			name:  "sdkmanrc with CRLF and unknown vendor",
+			parse: parseSdkmanrc,
+			data:  "java = 21.0.2-custom\r\n",
+			want:  []Pin{{SDKType: "jdk", Distribution: "custom", Version: "21.0.2"}},
+		},
+		{
+			name:  "java-version",
+			parse: parseJavaVersion,
+			data:  "# jenv\n\ntemurin64-17.0.9\n",
+			want:  []Pin{{SDKType: "jdk", Distribution: "temurin", Version: "17.0.9"}},
+		},
+		{
+			name:  "java-version legacy spelling with CRLF",
+			parse: parseJavaVersion,
+			data:  "1.8\r\n",
+			want:  []Pin{{SDKType: "jdk", Version: "8"}},
+		},
+		{
+			name:  "empty java-version",
+			parse: parseJavaVersion,
+			data:  "\n# nothing pinned\n",
+		},
+		{
+			name:  "nvmrc",
+			parse: parseNodeVersion,
+			data:  "v22.1.0\r\n",
+			want:  []Pin{{SDKType: "node", Version: "22.1.0"}},
+		},
+		{
+			name:  "nvmrc codename",
+			parse: parseNodeVersion,
+			data:  "\nlts/iron # LTS\n",
+			want:  []Pin{{SDKType: "node", Version: "20"}},
+		},
+		{
+			name:  "nvmrc alias",
+			parse: parseNodeVersion,
+			data:  "lts/*\n",
+			want:  []Pin{{SDKType: "node", Version: "lts"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParserErrors(t *testing.T) {
+	if _, err := parseStrigoFile([]byte("[jdk]\ndistribution = \"temurin\"\n")); err == nil {
+		t.Error("strigo file without version: want an error")
+	}
+	if _, err := parseSdkmanrc([]byte("java 17.0.2-tem\n")); err == nil {
+		t.Error("sdkmanrc without '=': want an error")
+	}
+}
+
+func TestFindNearestFileWins(t *testing.T) {
+	root := t.TempDir()
+	child := filepath.Join(root, "service", "api")
+	if err := os.MkdirAll(child, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(root, ".tool-versions", "java temurin-17.0.9\nnodejs 20.11.0\n")
+	write(filepath.Join(root, "service"), ".java-version", "21\n")
+	write(child, ".nvmrc", "v22.1.0\n")
+	write(child, FileName, "[jdk]\ndistribution = \"corretto\"\nversion = \"21.0.6\"\n")
+	write(child, ".sdkmanrc", "java=17.0.2-tem\n")
+
+	pins, err := Find(child)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	want := []Pin{
+		{SDKType: "jdk", Distribution: "corretto", Version: "21.0.6", Source: filepath.Join(child, FileName)},
+		{SDKType: "node", Version: "22.1.0", Source: filepath.Join(child, ".nvmrc")},
+	}
+	if !reflect.DeepEqual(pins, want) {
+		t.Errorf("Find(child) = %+v, want %+v", pins, want)
+	}
+
+	pins, err = Find(filepath.Join(root, "service"))
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	want = []Pin{
+		{SDKType: "jdk", Version: "21", Source: filepath.Join(root, "service", ".java-version")},
+		{SDKType: "node", Version: "20.11.0", Source: filepath.Join(root, ".tool-versions")},
+	}
+	if !reflect.DeepEqual(pins, want) {
+		t.Errorf("Find(service) = %+v, want %+v", pins, want)
+	}
+}
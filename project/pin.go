@@ -0,0 +1,120 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FileName is strigo's own project file
+const FileName = ".strigo.toml"
+
+// Pin is an SDK version required by a project directory
+type Pin struct {
+	SDKType      string `json:"sdk_type"`
+	Distribution string `json:"distribution,omitempty"` // Empty when the file does not name one
+	Version      string `json:"version"`                // Exact version, alias or range
+	Source       string `json:"source"`                 // File that declared the pin
+}
+
+// pinFile reads the pins declared by one kind of version file
+type pinFile struct {
+	name  string
+	parse func(data []byte) ([]Pin, error)
+}
+
+// pinFiles lists the recognised version files, by precedence within a directory
+var pinFiles = []pinFile{
+	{FileName, parseStrigoFile},
+	{".tool-versions", parseToolVersions},
+	{".sdkmanrc", parseSdkmanrc},
+	{".java-version", parseJavaVersion},
+	{".nvmrc", parseNodeVersion},
+	{".node-version", parseNodeVersion},
+}
+
+// FileNames returns the names of the recognised version files
+func FileNames() []string {
+	names := make([]string, len(pinFiles))
+	for i, file := range pinFiles {
+		names[i] = file.name
+	}
+	return names
+}
+
+// Find walks up from dir and returns, for each SDK type, the pin declared by the
+// nearest version file. Pins are sorted by SDK type.
+func Find(dir string) ([]Pin, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	found := make(map[string]Pin)
+	for {
+		pins, err := readDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, pin := range pins {
+			if _, pinned := found[pin.SDKType]; !pinned {
+				found[pin.SDKType] = pin
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	var pins []Pin
+	for _, pin := range found {
+		pins = append(pins, pin)
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		return pins[i].SDKType < pins[j].SDKType
+	})
+	return pins, nil
+}
+
+// FindType returns the pin of an SDK type for dir, or nil when none applies
+func FindType(dir, sdkType string) (*Pin, error) {
+	pins, err := Find(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, pin := range pins {
+		if pin.SDKType == sdkType {
+			return &pin, nil
+		}
+	}
+	return nil, nil
+}
+
+// readDir reads the pins declared by the version files of a single directory
+func readDir(dir string) ([]Pin, error) {
+	var pins []Pin
+	for _, file := range pinFiles {
+		path := filepath.Join(dir, file.name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) || os.IsPermission(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		filePins, err := file.parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", path, err)
+		}
+		for _, pin := range filePins {
+			pin.Source = path
+			pins = append(pins, pin)
+		}
+	}
+	return pins, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strigo/config"
+	"strigo/logging"
+	"strings"
+)
+
+// ArtifactoryClient implements RepositoryClient for JFrog Artifactory generic repositories
+type ArtifactoryClient struct {
+	credentials *config.Credentials
+}
+
+// artifactoryFile represents a file returned by the Artifactory storage list API
+type artifactoryFile struct {
+	URI    string `json:"uri"`
+	Size   int64  `json:"size"`
+	Folder bool   `json:"folder"`
+	SHA1   string `json:"sha1"`
+	SHA2   string `json:"sha2"`
+}
+
+// artifactoryListing represents the response of the Artifactory storage list API
+type artifactoryListing struct {
+	URI   string            `json:"uri"`
+	Files []artifactoryFile `json:"files"`
+}
+
+// ListAssets lists the files stored below the repository path in an Artifactory repository.
+// The registry api_url is the Artifactory base URL, e.g. https://artifactory.example.com/artifactory
+func (c *ArtifactoryClient) ListAssets(repo config.SDKRepository, registry config.Registry) ([]RemoteAsset, error) {
+	baseURL := strings.TrimSuffix(registry.APIURL, "/")
+	repoPath := strings.Trim(repo.Path, "/")
+
+	logging.LogDebug("🔍 Registry API URL: %s", baseURL)
+	logging.LogDebug("🔍 Repository: %s", repo.Repository)
+	logging.LogDebug("🔍 Path: %s", repoPath)
+
+	requestURL := fmt.Sprintf("%s/api/storage/%s/%s?list&deep=1&listFolders=0", baseURL, repo.Repository, repoPath)
+	logging.LogDebug("🔍 Final Artifactory API URL: %s", requestURL)
+
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Artifactory API request: %v", err)
+	}
+	c.credentials.Apply(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Artifactory API: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("artifactory API returned %d: check the credentials configured for this registry", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("artifactory API returned %d: Check if the path %s exists in repository %s", resp.StatusCode, repoPath, repo.Repository)
+	}
+
+	var listing artifactoryListing
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON response: %v", err)
+	}
+
+	logging.LogDebug("🔍 Raw files from Artifactory:")
+	logging.LogDebug("Found %d files in response", len(listing.Files))
+
+	var assets []RemoteAsset
+	for _, file := range listing.Files {
+		if file.Folder {
+			continue
+		}
+
+		// File URIs are relative to the listed folder
+		path := strings.TrimPrefix(repoPath+file.URI, "/")
+		downloadURL := fmt.Sprintf("%s/%s/%s", baseURL, repo.Repository, path)
+		logging.LogDebug("File path: %s, downloadUrl: %s", path, downloadURL)
+
+		assets = append(assets, RemoteAsset{
+			Path:        path,
+			DownloadUrl: downloadURL,
+			Size:        file.Size,
+			SHA256:      file.SHA2,
+			SHA1:        file.SHA1,
+		})
+	}
+
+	return assets, nil
+}
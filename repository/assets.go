@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strigo/config"
+	"strigo/logging"
+	"strigo/version"
+	"strings"
+)
+
+// SDKAsset represents an available version of an SDK
+type SDKAsset struct {
+	Version     string `json:"version"`
+	DownloadUrl string `json:"downloadUrl"`
+	Filename    string `json:"filename"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256,omitempty"`
+	SHA1        string `json:"sha1,omitempty"`
+	OS          string `json:"os,omitempty"`
+	Arch        string `json:"arch,omitempty"`
+}
+
+// RemoteAsset represents a file listed by a registry, before version extraction
+type RemoteAsset struct {
+	Path        string
+	DownloadUrl string
+	Size        int64
+	SHA256      string
+	SHA1        string
+}
+
+// SelectAssets turns the files of a registry into SDK assets.
+// Files outside the repository path or without a recognizable version are ignored.
+func SelectAssets(items []RemoteAsset, repo config.SDKRepository, versionFilter string) ([]SDKAsset, error) {
+	var sdkAssets []SDKAsset
+	seenAssets := make(map[string]bool) // Pour suivre les versions déjà vues, par plateforme
+
+	matches, err := ExplainAssets(items, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var ignoredFiles []string
+	for i, match := range matches {
+		if match.Ignored != "" {
+			ignoredFiles = append(ignoredFiles, match.Path)
+			continue
+		}
+
+		// Vérifier si cette version a déjà été vue pour cette plateforme
+		key := match.Version + "|" + match.OS + "|" + match.Arch
+		if !seenAssets[key] {
+			seenAssets[key] = true
+			item := items[i]
+			sdkAsset := SDKAsset{
+				Version:     match.Version,
+				DownloadUrl: item.DownloadUrl,
+				Filename:    match.Version,
+				Size:        item.Size,
+				SHA256:      item.SHA256,
+				SHA1:        item.SHA1,
+				OS:          match.OS,
+				Arch:        match.Arch,
+			}
+			sdkAssets = append(sdkAssets, sdkAsset)
+		}
+	}
+
+	if len(ignoredFiles) > 0 {
+		logging.LogDebug("❌ Ignored files:")
+		for _, f := range ignoredFiles {
+			logging.LogDebug("   - %s", f)
+		}
+	}
+
+	// Filtrer les versions si un filtre est spécifié
+	if versionFilter != "" {
+		var filteredAssets []SDKAsset
+		for _, asset := range sdkAssets {
+			if strings.Contains(asset.Version, versionFilter) {
+				filteredAssets = append(filteredAssets, asset)
+			}
+		}
+		sdkAssets = filteredAssets
+	}
+
+	if len(sdkAssets) == 0 {
+		if versionFilter != "" {
+			return nil, fmt.Errorf("no version %s found for %s", versionFilter, repo.Path)
+		}
+		return nil, fmt.Errorf("no versions found for %s", repo.Path)
+	}
+
+	// Trier les versions
+	sort.SliceStable(sdkAssets, func(i, j int) bool {
+		return version.Less(sdkAssets[j].Version, sdkAssets[i].Version)
+	})
+
+	return sdkAssets, nil
+}
+
+// ExtractVersionName extracts the versioned filename from a registry path.
+func ExtractVersionName(path string) string {
+	version, _ := extractBuiltinVersion(path)
+	return version
+}
+
+// extractBuiltinVersion extracts a version with the built-in patterns and
+// returns the pattern that matched.
+func extractBuiltinVersion(path string) (string, string) {
+	logging.LogDebug("Extracting version from path: %s", path)
+
+	// Handle different naming patterns
+	patterns := []string{
+		`corretto-(\d+\.\d+\.\d+\.\d+)`,                    // For Corretto: 11.0.26.4.1
+		`jdk-(\d+\.\d+\.\d+_\d+)`,                          // For Temurin: 11.0.26_4
+		`jdk_[a-z0-9]+_[a-z]+_hotspot_(\d+\.\d+\.\d+_\d+)`, // Alternative Temurin pattern
+		`(\d+u\d+\w+)`,                                     // For older versions: 8u442b06
+		`node-v(\d+\.\d+\.\d+)-[a-z]+-[a-z0-9]+`,           // For Node.js: node-v22.13.1-linux-x64
+		`amazon-corretto-(\d+\.\d+\.\d+\.\d+)`,             // For Amazon Corretto
+		`zulu\d+\.\d+\.\d+-ca-jdk(\d+\.\d+\.\d+)`,          // For Zulu
+	}
+
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(pattern)
+		if matches := re.FindStringSubmatch(path); len(matches) > 1 {
+			logging.LogDebug("  Found version %s using pattern %s", matches[1], pattern)
+			return matches[1], pattern
+		}
+	}
+
+	// Fallback: try to extract version from path components
+	parts := strings.Split(path, "/")
+	for _, part := range parts {
+		logging.LogDebug("  Checking path component: %s", part)
+
+		// Look for version-like patterns in path components
+		if strings.HasPrefix(part, "v") {
+			version := strings.TrimPrefix(part, "v")
+			if _, err := strconv.Atoi(strings.Split(version, ".")[0]); err == nil {
+				logging.LogDebug("  Found version in path component: %s", version)
+				return version, "path component v<version>"
+			}
+		}
+
+		// Check for version in the format jdk-X.Y.Z or jdkX.Y.Z
+		if strings.Contains(part, "jdk") {
+			version := strings.TrimPrefix(strings.TrimPrefix(part, "jdk-"), "jdk")
+			if _, err := strconv.Atoi(strings.Split(version, ".")[0]); err == nil {
+				logging.LogDebug("  Found version in JDK component: %s", version)
+				return version, "path component jdk<version>"
+			}
+		}
+	}
+
+	logging.LogDebug("  No version found in path")
+	return "", ""
+}
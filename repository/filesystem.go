@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strigo/config"
+	"strigo/logging"
+	"strings"
+)
+
+// FilesystemClient implements RepositoryClient for local or network-mounted directories
+type FilesystemClient struct{}
+
+// ListAssets walks the directory tree below the repository path.
+// The registry api_url is either a plain directory or a file:// URL, e.g. file:///mnt/sdk-mirror
+func (c *FilesystemClient) ListAssets(repo config.SDKRepository, registry config.Registry) ([]RemoteAsset, error) {
+	root, err := FilesystemRoot(registry.APIURL)
+	if err != nil {
+		return nil, err
+	}
+	if repo.Repository != "" {
+		root = filepath.Join(root, repo.Repository)
+	}
+	searchPath := filepath.Join(root, filepath.FromSlash(repo.Path))
+
+	logging.LogDebug("🔍 Registry root: %s", root)
+	logging.LogDebug("🔍 Path: %s", repo.Path)
+	logging.LogDebug("🔍 Scanning directory: %s", searchPath)
+
+	if _, err := os.Stat(searchPath); err != nil {
+		return nil, fmt.Errorf("failed to access %s: Check if the path %s exists in the registry", searchPath, repo.Path)
+	}
+
+	var assets []RemoteAsset
+	err = filepath.WalkDir(searchPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || isChecksumFile(path) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		asset := RemoteAsset{
+			Path:        filepath.ToSlash(relPath),
+			DownloadUrl: (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String(),
+			Size:        info.Size(),
+			SHA256:      readChecksumFile(path + ".sha256"),
+			SHA1:        readChecksumFile(path + ".sha1"),
+		}
+		logging.LogDebug("File path: %s, downloadUrl: %s", asset.Path, asset.DownloadUrl)
+		assets = append(assets, asset)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", searchPath, err)
+	}
+
+	logging.LogDebug("Found %d files in %s", len(assets), searchPath)
+	return assets, nil
+}
+
+// FilesystemRoot converts a filesystem registry api_url to a directory path
+func FilesystemRoot(apiURL string) (string, error) {
+	if strings.HasPrefix(apiURL, "file://") {
+		u, err := url.Parse(apiURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid filesystem registry URL %s: %w", apiURL, err)
+		}
+		return filepath.FromSlash(u.Path), nil
+	}
+	return config.ExpandTilde(apiURL)
+}
+
+func isChecksumFile(path string) bool {
+	return strings.HasSuffix(path, ".sha256") || strings.HasSuffix(path, ".sha1")
+}
+
+// readChecksumFile reads a sidecar checksum file in the "<digest>  <filename>" format
+func readChecksumFile(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
@@ -4,190 +4,163 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"regexp"
-	"sort"
-	"strconv"
+	"net/url"
 	"strigo/config"
 	"strigo/logging"
 	"strings"
 )
 
-// SDKAsset represents an available version of an SDK
-type SDKAsset struct {
-	Version     string `json:"version"`
-	DownloadUrl string `json:"downloadUrl"`
-	Filename    string `json:"filename"`
-	Size        int64  `json:"size"`
-}
-
 // NexusClient implements RepositoryClient for Nexus repositories
-type NexusClient struct{}
+type NexusClient struct {
+	credentials *config.Credentials
+}
 
 // NexusAsset represents an asset returned by Nexus API
 type NexusAsset struct {
 	Path        string            `json:"path"`
 	DownloadUrl string            `json:"downloadUrl"`
 	Checksum    map[string]string `json:"checksum"`
+	FileSize    int64             `json:"fileSize"`
 }
 
-// GetAvailableVersions fetches available versions of a JDK from a Nexus repository.
-func (c *NexusClient) GetAvailableVersions(repo config.SDKRepository, registry config.Registry, versionFilter string) ([]SDKAsset, error) {
-	var sdkAssets []SDKAsset
-	var ignoredFiles []string
-	seenVersions := make(map[string]bool) // Pour suivre les versions déjà vues
+// nexusPage represents one page of the Nexus assets or search API
+type nexusPage struct {
+	Items             []NexusAsset `json:"items"`
+	ContinuationToken string       `json:"continuationToken"`
+}
 
+// ListAssets lists the files stored below the repository path in a Nexus repository.
+func (c *NexusClient) ListAssets(repo config.SDKRepository, registry config.Registry) ([]RemoteAsset, error) {
 	// Ensure apiURL is correctly formatted and replace placeholders
 	logging.LogDebug("🔍 Registry API URL: %s", registry.APIURL)
 	logging.LogDebug("🔍 Repository: %s", repo.Repository)
 	logging.LogDebug("🔍 Path: %s", repo.Path)
 
-	apiURL := strings.ReplaceAll(registry.APIURL, "{repository}", repo.Repository)
-	logging.LogDebug("🔍 API URL after repository replacement: %s", apiURL)
-
-	// Build final request URL
-	requestURL := fmt.Sprintf("%s&path=%s", apiURL, repo.Path)
-
-	logging.LogDebug("🔍 Final Nexus API URL: %s", requestURL)
-
-	resp, err := http.Get(requestURL)
+	requestURL, err := buildNexusRequestURL(repo, registry)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query Nexus API: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("nexus API returned %d: Check if the path %s exists in Nexus", resp.StatusCode, repo.Path)
-	}
+	logging.LogDebug("🔍 Final Nexus API URL: %s", requestURL)
 
-	// Parse JSON response
-	var data struct {
-		Items []NexusAsset `json:"items"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, fmt.Errorf("failed to decode JSON response: %v", err)
+	items, err := c.fetchAllItems(requestURL, repo.Path)
+	if err != nil {
+		return nil, err
 	}
 
 	logging.LogDebug("🔍 Raw items from Nexus:")
-	logging.LogDebug("Found %d items in response", len(data.Items))
-	for _, item := range data.Items {
+	logging.LogDebug("Found %d items in response", len(items))
+
+	assets := make([]RemoteAsset, 0, len(items))
+	for _, item := range items {
 		logging.LogDebug("Item path: %s, downloadUrl: %s", item.Path, item.DownloadUrl)
+		assets = append(assets, RemoteAsset{
+			Path:        item.Path,
+			DownloadUrl: item.DownloadUrl,
+			Size:        item.FileSize,
+			SHA256:      item.Checksum["sha256"],
+			SHA1:        item.Checksum["sha1"],
+		})
 	}
 
-	// Construire le chemin complet pour la distribution
-	distributionPath := repo.Path
-	logging.LogDebug("Looking for distribution path: %s", distributionPath)
-
-	for _, item := range data.Items {
-		logging.LogDebug("   Path: %s", item.Path)
+	return assets, nil
+}
 
-		// Vérifier si le chemin correspond à la distribution demandée
-		if !strings.Contains(item.Path, distributionPath) && distributionPath != "" {
-			logging.LogDebug("   Ignoring file: path does not contain %s", distributionPath)
-			ignoredFiles = append(ignoredFiles, item.Path)
-			continue
+// buildNexusRequestURL builds the listing URL for a repository path.
+// When a search API URL is configured, the Nexus search endpoint is used with a
+// group filter so that only the assets below the configured path are returned.
+func buildNexusRequestURL(repo config.SDKRepository, registry config.Registry) (string, error) {
+	if registry.SearchAPIURL != "" {
+		searchURL := strings.ReplaceAll(registry.SearchAPIURL, "{repository}", repo.Repository)
+		logging.LogDebug("🔍 Search API URL after repository replacement: %s", searchURL)
+
+		u, err := url.Parse(searchURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid Nexus search API URL %s: %w", searchURL, err)
 		}
-
-		versionName := ExtractVersionName(item.Path)
-		if versionName != "" {
-			logging.LogDebug("   Extracted version: %s from path: %s", versionName, item.Path)
-			// Vérifier si cette version a déjà été vue
-			if !seenVersions[versionName] {
-				seenVersions[versionName] = true
-				sdkAsset := SDKAsset{
-					Version:     versionName,
-					DownloadUrl: item.DownloadUrl,
-					Filename:    versionName,
-					// Size sera ajouté plus tard si nécessaire
-				}
-				sdkAssets = append(sdkAssets, sdkAsset)
-			}
-		} else {
-			ignoredFiles = append(ignoredFiles, item.Path)
+		query := u.Query()
+		if repo.Path != "" {
+			query.Set("group", "/"+strings.Trim(repo.Path, "/")+"*")
 		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
 	}
 
-	if len(ignoredFiles) > 0 {
-		logging.LogDebug("❌ Ignored files:")
-		for _, f := range ignoredFiles {
-			logging.LogDebug("   - %s", f)
-		}
+	apiURL := strings.ReplaceAll(registry.APIURL, "{repository}", repo.Repository)
+	logging.LogDebug("🔍 API URL after repository replacement: %s", apiURL)
+
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid Nexus API URL %s: %w", apiURL, err)
 	}
+	query := u.Query()
+	query.Set("path", repo.Path)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
 
-	// Filtrer les versions si un filtre est spécifié
-	if versionFilter != "" {
-		var filteredAssets []SDKAsset
-		for _, asset := range sdkAssets {
-			if strings.Contains(asset.Version, versionFilter) {
-				filteredAssets = append(filteredAssets, asset)
+// fetchAllItems walks every page of a Nexus listing by following the continuationToken.
+func (c *NexusClient) fetchAllItems(requestURL, path string) ([]NexusAsset, error) {
+	var items []NexusAsset
+	continuationToken := ""
+
+	for page := 1; ; page++ {
+		pageURL := requestURL
+		if continuationToken != "" {
+			u, err := url.Parse(requestURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Nexus API URL %s: %w", requestURL, err)
 			}
+			query := u.Query()
+			query.Set("continuationToken", continuationToken)
+			u.RawQuery = query.Encode()
+			pageURL = u.String()
 		}
-		sdkAssets = filteredAssets
-	}
 
-	if len(sdkAssets) == 0 {
-		if versionFilter != "" {
-			return nil, fmt.Errorf("no version %s found for %s", versionFilter, repo.Path)
+		logging.LogDebug("📄 Fetching Nexus page %d: %s", page, pageURL)
+		data, err := c.fetchPage(pageURL, path)
+		if err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("no versions found for %s", repo.Path)
-	}
-
-	// Trier les versions
-	sort.Slice(sdkAssets, func(i, j int) bool {
-		return sdkAssets[i].Version > sdkAssets[j].Version
-	})
+		items = append(items, data.Items...)
 
-	return sdkAssets, nil
+		if data.ContinuationToken == "" {
+			logging.LogDebug("✅ Fetched %d items from %d page(s)", len(items), page)
+			return items, nil
+		}
+		if data.ContinuationToken == continuationToken {
+			return nil, fmt.Errorf("nexus API returned the same continuationToken twice: %s", continuationToken)
+		}
+		continuationToken = data.ContinuationToken
+	}
 }
 
-// ExtractVersionName extracts the versioned filename from a Nexus path.
-func ExtractVersionName(path string) string {
-	logging.LogDebug("Extracting version from path: %s", path)
-
-	// Handle different naming patterns
-	patterns := []string{
-		`corretto-(\d+\.\d+\.\d+\.\d+)`,             // For Corretto: 11.0.26.4.1
-		`jdk-(\d+\.\d+\.\d+_\d+)`,                   // For Temurin: 11.0.26_4
-		`jdk_x64_linux_hotspot_(\d+\.\d+\.\d+_\d+)`, // Alternative Temurin pattern
-		`(\d+u\d+\w+)`,                              // For older versions: 8u442b06
-		`node-v(\d+\.\d+\.\d+)-linux-x64`,           // For Node.js: node-v22.13.1-linux-x64
-		`amazon-corretto-(\d+\.\d+\.\d+\.\d+)`,      // For Amazon Corretto
-		`zulu\d+\.\d+\.\d+-ca-jdk(\d+\.\d+\.\d+)`,   // For Zulu
+// fetchPage retrieves and decodes a single page of the Nexus listing.
+func (c *NexusClient) fetchPage(pageURL, path string) (*nexusPage, error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Nexus API request: %v", err)
 	}
+	c.credentials.Apply(req)
 
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		if matches := re.FindStringSubmatch(path); len(matches) > 1 {
-			logging.LogDebug("  Found version %s using pattern %s", matches[1], pattern)
-			return matches[1]
-		}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Nexus API: %v", err)
 	}
+	defer resp.Body.Close()
 
-	// Fallback: try to extract version from path components
-	parts := strings.Split(path, "/")
-	for _, part := range parts {
-		logging.LogDebug("  Checking path component: %s", part)
-
-		// Look for version-like patterns in path components
-		if strings.HasPrefix(part, "v") {
-			version := strings.TrimPrefix(part, "v")
-			if _, err := strconv.Atoi(strings.Split(version, ".")[0]); err == nil {
-				logging.LogDebug("  Found version in path component: %s", version)
-				return version
-			}
-		}
-
-		// Check for version in the format jdk-X.Y.Z or jdkX.Y.Z
-		if strings.Contains(part, "jdk") {
-			version := strings.TrimPrefix(strings.TrimPrefix(part, "jdk-"), "jdk")
-			if _, err := strconv.Atoi(strings.Split(version, ".")[0]); err == nil {
-				logging.LogDebug("  Found version in JDK component: %s", version)
-				return version
-			}
-		}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("nexus API returned %d: check the credentials configured for this registry", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nexus API returned %d: Check if the path %s exists in Nexus", resp.StatusCode, path)
 	}
 
-	logging.LogDebug("  No version found in path")
-	return ""
+	var data nexusPage
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON response: %v", err)
+	}
+	return &data, nil
 }
 
 // FindAssetByVersion helps locate a specific asset in the version map
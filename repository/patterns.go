@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strigo/config"
+	"strigo/logging"
+	"strings"
+)
+
+// Named groups recognized in configured filename patterns
+const (
+	groupVersion = "version"
+	groupOS      = "os"
+	groupArch    = "arch"
+)
+
+// PathMatch describes how a registry path was handled during version extraction
+type PathMatch struct {
+	Path    string `json:"path"`
+	Version string `json:"version,omitempty"`
+	OS      string `json:"os,omitempty"`
+	Arch    string `json:"arch,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	Ignored string `json:"ignored,omitempty"`
+}
+
+// compilePatterns compiles the filename patterns declared on a repository.
+// A pattern must either define a (?P<version>...) group or capture the version in its first group.
+func compilePatterns(repo config.SDKRepository) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range repo.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q for repository %s: %w", pattern, repo.Path, err)
+		}
+		if re.NumSubexp() == 0 {
+			return nil, fmt.Errorf("pattern %q for repository %s has no capture group for the version", pattern, repo.Path)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// matchPath extracts version, os and arch from a path with the configured
+// patterns, falling back to the built-in patterns.
+func matchPath(path string, patterns []*regexp.Regexp) PathMatch {
+	match := PathMatch{Path: path}
+
+	for _, re := range patterns {
+		submatches := re.FindStringSubmatch(path)
+		if submatches == nil {
+			continue
+		}
+
+		version := submatches[1]
+		for i, name := range re.SubexpNames() {
+			switch name {
+			case groupVersion:
+				version = submatches[i]
+			case groupOS:
+				match.OS = submatches[i]
+			case groupArch:
+				match.Arch = submatches[i]
+			}
+		}
+		if version == "" {
+			continue
+		}
+
+		logging.LogDebug("  Found version %s using configured pattern %s", version, re.String())
+		match.Version = version
+		match.Pattern = re.String()
+		completePlatform(&match)
+		return match
+	}
+
+	version, pattern := extractBuiltinVersion(path)
+	if version == "" {
+		match.Ignored = "no pattern matched"
+		return match
+	}
+	match.Version = version
+	match.Pattern = pattern
+	completePlatform(&match)
+	return match
+}
+
+// completePlatform normalizes the captured os/arch and detects missing ones from the file name
+func completePlatform(match *PathMatch) {
+	detectedOS, detectedArch := detectPlatform(match.Path)
+	if match.OS == "" {
+		match.OS = detectedOS
+	}
+	if match.Arch == "" {
+		match.Arch = detectedArch
+	}
+	if match.OS != "" {
+		match.OS = NormalizeOS(match.OS)
+	}
+	if match.Arch != "" {
+		match.Arch = NormalizeArch(match.Arch)
+	}
+}
+
+// ExplainAssets reports, for every listed file, which pattern extracted its
+// version or why it was ignored.
+func ExplainAssets(items []RemoteAsset, repo config.SDKRepository) ([]PathMatch, error) {
+	patterns, err := compilePatterns(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Construire le chemin complet pour la distribution
+	distributionPath := repo.Path
+	logging.LogDebug("Looking for distribution path: %s", distributionPath)
+
+	matches := make([]PathMatch, 0, len(items))
+	for _, item := range items {
+		logging.LogDebug("   Path: %s", item.Path)
+
+		// Vérifier si le chemin correspond à la distribution demandée
+		if !strings.Contains(item.Path, distributionPath) && distributionPath != "" {
+			logging.LogDebug("   Ignoring file: path does not contain %s", distributionPath)
+			matches = append(matches, PathMatch{Path: item.Path, Ignored: fmt.Sprintf("path does not contain %s", distributionPath)})
+			continue
+		}
+
+		match := matchPath(item.Path, patterns)
+		if match.Ignored == "" {
+			logging.LogDebug("   Extracted version: %s from path: %s", match.Version, item.Path)
+		}
+		matches = append(matches, match)
+	}
+
+	return matches, nil
+}
+
+// ExplainAvailableVersions lists a repository and reports how each path was matched, without filtering
+func ExplainAvailableVersions(repo config.SDKRepository, registry config.Registry) ([]PathMatch, error) {
+	client, err := NewRepositoryClient(repo.Registry, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := client.ListAssets(repo, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExplainAssets(items, repo)
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// osAliases maps operating system names found in file names to GOOS values
+var osAliases = map[string]string{
+	"linux":   "linux",
+	"alpine":  "linux",
+	"mac":     "darwin",
+	"macos":   "darwin",
+	"macosx":  "darwin",
+	"osx":     "darwin",
+	"darwin":  "darwin",
+	"windows": "windows",
+	"win":     "windows",
+	"aix":     "aix",
+	"solaris": "solaris",
+}
+
+// archAliases maps architecture names found in file names to GOARCH values
+var archAliases = map[string]string{
+	"x64":     "amd64",
+	"amd64":   "amd64",
+	"aarch64": "arm64",
+	"arm64":   "arm64",
+	"x86":     "386",
+	"x32":     "386",
+	"i386":    "386",
+	"i686":    "386",
+	"ppc64le": "ppc64le",
+	"s390x":   "s390x",
+	"arm":     "arm",
+	"arm32":   "arm",
+	"armv7l":  "arm",
+	"armhf":   "arm",
+}
+
+// NormalizeOS converts an operating system name to its GOOS value
+func NormalizeOS(name string) string {
+	if goos, ok := osAliases[strings.ToLower(name)]; ok {
+		return goos
+	}
+	return strings.ToLower(name)
+}
+
+// NormalizeArch converts an architecture name to its GOARCH value
+func NormalizeArch(name string) string {
+	lower := strings.ToLower(name)
+	if lower == "x86_64" || lower == "x86-64" {
+		return "amd64"
+	}
+	if goarch, ok := archAliases[lower]; ok {
+		return goarch
+	}
+	return lower
+}
+
+// detectPlatform guesses the operating system and architecture from a file name
+func detectPlatform(filePath string) (string, string) {
+	name := strings.ToLower(path.Base(filePath))
+	name = strings.NewReplacer("x86_64", "x64", "x86-64", "x64").Replace(name)
+
+	tokens := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+
+	var goos, goarch string
+	for _, token := range tokens {
+		if value, ok := osAliases[token]; ok && goos == "" {
+			goos = value
+		}
+		if value, ok := archAliases[token]; ok && goarch == "" {
+			goarch = value
+		}
+	}
+	return goos, goarch
+}
+
+// Platform returns the os/arch pair of an asset, e.g. linux/amd64
+func (a SDKAsset) Platform() string {
+	goos, goarch := a.OS, a.Arch
+	if goos == "" {
+		goos = "any"
+	}
+	if goarch == "" {
+		goarch = "any"
+	}
+	return goos + "/" + goarch
+}
+
+// SelectPlatformAsset picks the asset of a version built for the requested platform.
+// Assets whose platform could not be determined are used as a fallback.
+func SelectPlatformAsset(assets []SDKAsset, version, goos, goarch string) (*SDKAsset, error) {
+	goos, goarch = NormalizeOS(goos), NormalizeArch(goarch)
+
+	var fallback *SDKAsset
+	var platforms []string
+	for i := range assets {
+		asset := &assets[i]
+		if asset.Version != version {
+			continue
+		}
+		platforms = append(platforms, asset.Platform())
+
+		osMatches := asset.OS == "" || asset.OS == goos
+		archMatches := asset.Arch == "" || asset.Arch == goarch
+		if !osMatches || !archMatches {
+			continue
+		}
+		if asset.OS == goos && asset.Arch == goarch {
+			return asset, nil
+		}
+		if fallback == nil {
+			fallback = asset
+		}
+	}
+
+	if fallback != nil {
+		return fallback, nil
+	}
+	if len(platforms) == 0 {
+		return nil, nil
+	}
+
+	sort.Strings(platforms)
+	return nil, fmt.Errorf("version %s is not available for %s/%s (available platforms: %s)",
+		version, goos, goarch, strings.Join(platforms, ", "))
+}
+
+// PlatformVersions lists the distinct versions having an asset usable on the requested platform
+func PlatformVersions(assets []SDKAsset, goos, goarch string) []string {
+	goos, goarch = NormalizeOS(goos), NormalizeArch(goarch)
+
+	seen := make(map[string]bool)
+	var versions []string
+	for _, asset := range assets {
+		if seen[asset.Version] {
+			continue
+		}
+		if (asset.OS == "" || asset.OS == goos) && (asset.Arch == "" || asset.Arch == goarch) {
+			seen[asset.Version] = true
+			versions = append(versions, asset.Version)
+		}
+	}
+	return versions
+}
@@ -0,0 +1,158 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Aliases accepted in place of a version
+const (
+	AliasLatest = "latest"
+	AliasLTS    = "lts"
+)
+
+// ErrNoMatch is returned when no candidate satisfies the requested version
+var ErrNoMatch = errors.New("no matching version")
+
+// prefixPattern matches a partial version such as 21 or 17.0
+var prefixPattern = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+// comparisonPattern matches one term of a range, such as >=17 or <22
+var comparisonPattern = regexp.MustCompile(`^(>=|<=|>|<|=|!=)\s*(\d+(?:\.\d+)*)$`)
+
+// Resolve picks the newest candidate matching expr, which is either:
+//   - an exact version (11.0.24_8, 8u442b06), possibly spelled differently (11.0.24+8)
+//   - "latest", the newest candidate
+//   - "lts", the newest candidate whose major version is in lts
+//   - a partial version (21, 17.0), the newest candidate starting with it
+//   - a range of space-separated comparisons (>=17 <22), all of which must hold
+//
+// Pre-releases (23.0.0-rc.1) are only selected when expr names them exactly.
+func Resolve(expr string, candidates []string, lts []int) (string, error) {
+	expr = strings.TrimSpace(expr)
+	for _, candidate := range candidates {
+		if candidate == expr {
+			return candidate, nil
+		}
+	}
+
+	// The same version spelled differently, as in version files (21.0.6+7 for 21.0.6_7)
+	if want := Parse(expr); want.IsNumeric() && !IsExpression(expr) {
+		for _, candidate := range candidates {
+			if Compare(Parse(candidate), want) == 0 {
+				return candidate, nil
+			}
+		}
+	}
+
+	match, err := matcher(expr, lts)
+	if err != nil {
+		return "", err
+	}
+
+	var best *Version
+	for _, candidate := range candidates {
+		v := Parse(candidate)
+		if v.Pre != "" {
+			continue
+		}
+		if match(v) && (best == nil || Compare(v, *best) > 0) {
+			best = &v
+		}
+	}
+	if best == nil {
+		return "", fmt.Errorf("%w for %q", ErrNoMatch, expr)
+	}
+	return best.Raw, nil
+}
+
+// IsExpression reports whether expr is an alias, a partial version or a range rather than an exact version
+func IsExpression(expr string) bool {
+	expr = strings.TrimSpace(expr)
+	return expr == AliasLatest || expr == AliasLTS || prefixPattern.MatchString(expr) || isRange(expr)
+}
+
+// matcher builds the predicate selecting the candidates of an expression
+func matcher(expr string, lts []int) (func(Version) bool, error) {
+	switch {
+	case expr == AliasLatest:
+		return func(Version) bool { return true }, nil
+	case expr == AliasLTS:
+		if len(lts) == 0 {
+			return nil, fmt.Errorf("no LTS versions configured for this SDK type")
+		}
+		return func(v Version) bool {
+			for _, major := range lts {
+				if v.IsNumeric() && v.Major == major {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case prefixPattern.MatchString(expr):
+		prefix := Parse(expr)
+		return func(v Version) bool { return hasPrefix(v, prefix) }, nil
+	case isRange(expr):
+		return parseRange(expr)
+	}
+	return nil, fmt.Errorf("%w for %q", ErrNoMatch, expr)
+}
+
+// isRange reports whether expr starts with a comparison operator
+func isRange(expr string) bool {
+	return expr != "" && strings.ContainsRune("<>=!", rune(expr[0]))
+}
+
+// parseRange parses space-separated comparisons. Partial versions cover every
+// version starting with them: <=21 accepts 21.0.6, >21 starts at 22.
+func parseRange(expr string) (func(Version) bool, error) {
+	var terms []func(Version) bool
+	for _, term := range splitTerms(expr) {
+		matches := comparisonPattern.FindStringSubmatch(term)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid version range term %q in %q", term, expr)
+		}
+
+		bound := Parse(matches[2])
+		switch matches[1] {
+		case ">=":
+			terms = append(terms, func(v Version) bool { return Compare(v, bound) >= 0 })
+		case ">":
+			upper := next(bound)
+			terms = append(terms, func(v Version) bool { return Compare(v, upper) >= 0 })
+		case "<":
+			terms = append(terms, func(v Version) bool { return Compare(v, bound) < 0 })
+		case "<=":
+			upper := next(bound)
+			terms = append(terms, func(v Version) bool { return Compare(v, upper) < 0 })
+		case "=":
+			terms = append(terms, func(v Version) bool { return hasPrefix(v, bound) })
+		case "!=":
+			terms = append(terms, func(v Version) bool { return !hasPrefix(v, bound) })
+		}
+	}
+
+	return func(v Version) bool {
+		for _, term := range terms {
+			if !term(v) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// splitTerms splits a range on spaces, keeping operators attached to their version (">= 17" -> ">=17")
+func splitTerms(expr string) []string {
+	var terms []string
+	for _, field := range strings.Fields(expr) {
+		if len(terms) > 0 && strings.Trim(terms[len(terms)-1], "<>=!") == "" {
+			terms[len(terms)-1] += field
+			continue
+		}
+		terms = append(terms, field)
+	}
+	return terms
+}
@@ -0,0 +1,103 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	jdks := []string{"8u442b06", "11.0.24_8", "17.0.9+9", "17.0.13+11", "21.0.6+7", "22.0.2+9"}
+	nodes := []string{"20.18.1", "22.13.1", "22.9.0", "23.0.0-rc.1"}
+	lts := []int{8, 11, 17, 21}
+
+	tests := []struct {
+		name       string
+		expr       string
+		candidates []string
+		lts        []int
+		want       string
+	}{
+		{"exact", "17.0.9+9", jdks, lts, "17.0.9+9"},
+		{"exact spelled differently", "21.0.6_7", jdks, lts, "21.0.6+7"},
+		{"legacy spelled differently", "8u442-b06", jdks, lts, "8u442b06"},
+		{"latest", "latest", jdks, lts, "22.0.2+9"},
+		{"lts", "lts", jdks, lts, "21.0.6+7"},
+		{"major", "17", jdks, lts, "17.0.13+11"},
+		{"major and minor", "17.0", jdks, lts, "17.0.13+11"},
+		{"legacy major", "8", jdks, lts, "8u442b06"},
+		{"range", ">=17 <22", jdks, lts, "21.0.6+7"},
+		{"range with spaces", ">= 17 < 21", jdks, lts, "17.0.13+11"},
+		{"inclusive partial bound", "<=21", jdks, lts, "21.0.6+7"},
+		{"exclusive partial bound", ">21", jdks, lts, "22.0.2+9"},
+		{"exclusion", ">=17 !=22", jdks, lts, "21.0.6+7"},
+		{"equality", "=11", jdks, lts, "11.0.24_8"},
+		{"node latest skips pre-releases", "latest", nodes, nil, "22.13.1"},
+		{"node major", "22", nodes, nil, "22.13.1"},
+		{"node range skips pre-releases", ">=22", nodes, nil, "22.13.1"},
+		{"node exact pre-release", "23.0.0-rc.1", nodes, nil, "23.0.0-rc.1"},
+		{"node pre-release spelled differently", "v23.0.0-rc.1", nodes, nil, "23.0.0-rc.1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Resolve(test.expr, test.candidates, test.lts)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", test.expr, err)
+			}
+			if got != test.want {
+				t.Errorf("Resolve(%q) = %q, want %q", test.expr, got, test.want)
+			}
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	jdks := []string{"11.0.24_8", "17.0.13+11", "21.0.6+7"}
+
+	tests := []struct {
+		name       string
+		expr       string
+		candidates []string
+		lts        []int
+		noMatch    bool
+	}{
+		{"missing major", "23", jdks, nil, true},
+		{"empty range", ">=22", jdks, nil, true},
+		{"unknown exact version", "17.0.99+1", jdks, nil, true},
+		{"only pre-releases", "23", []string{"23.0.0-rc.1"}, nil, true},
+		{"lts not configured", "lts", jdks, nil, false},
+		{"invalid range", ">=abc", jdks, nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Resolve(test.expr, test.candidates, test.lts)
+			if err == nil {
+				t.Fatalf("Resolve(%q) = %q, want an error", test.expr, got)
+			}
+			if noMatch := errors.Is(err, ErrNoMatch); noMatch != test.noMatch {
+				t.Errorf("Resolve(%q) error = %v, ErrNoMatch %v, want %v", test.expr, err, noMatch, test.noMatch)
+			}
+		})
+	}
+}
+
+func TestIsExpression(t *testing.T) {
+	tests := map[string]bool{
+		"latest":      true,
+		"lts":         true,
+		"21":          true,
+		"17.0":        true,
+		">=17 <22":    true,
+		"!=22":        true,
+		"21.0.6+7":    false,
+		"8u442b06":    false,
+		"23.0.0-rc.1": false,
+	}
+
+	for expr, want := range tests {
+		if got := IsExpression(expr); got != want {
+			t.Errorf("IsExpression(%q) = %v, want %v", expr, got, want)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package version
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind identifies the version scheme a version string was parsed with
+type Kind string
+
+// Supported version schemes
+const (
+	KindJEP223   Kind = "jep223"   // 21.0.6+7, 11.0.24_8 (Temurin writes + as _)
+	KindLegacy   Kind = "legacy"   // 8u442b06, 8u442-b06, 1.8.0_442-b06
+	KindCorretto Kind = "corretto" // 21.0.6.7.1, 8.442.06.1
+	KindSemver   Kind = "semver"   // v22.11.0, 23.0.0-rc.1
+	KindGeneric  Kind = "generic"  // anything else, compared on its numbers
+)
+
+var (
+	legacyPattern    = regexp.MustCompile(`^(?:1\.)?(\d+)(?:u|\.0_)(\d+)(?:-?b(\d+))?$`)
+	corretto8Pattern = regexp.MustCompile(`^(8)\.(\d{3})\.(\d{2})\.(\d+)$`)
+	correttoPattern  = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)\.(\d+)\.(\d+)$`)
+	semverPattern    = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+	jep223Pattern    = regexp.MustCompile(`^(\d+)((?:\.\d+)*)(?:[+_](\d+))?$`)
+	numberPattern    = regexp.MustCompile(`\d+`)
+)
+
+// Version is a parsed, comparable version.
+// Versions are ordered by Major, Minor, Patch, Extra, Build, Revision, then pre-release.
+type Version struct {
+	Raw      string
+	Kind     Kind
+	Major    int
+	Minor    int
+	Patch    int   // Security or update level for JDKs (442 in 8u442b06)
+	Extra    []int // Components after the patch (JEP 223 patch levels)
+	Build    int
+	Revision int    // Corretto revision (1 in 21.0.6.7.1)
+	Pre      string // Semver pre-release; a pre-release sorts before its release
+
+	// precision is the number of components given before the build, used for prefix matching
+	precision int
+}
+
+// Parse parses a version string with the first matching scheme. Every string
+// can be parsed: unknown schemes fall back to their numeric components.
+func Parse(raw string) Version {
+	s := strings.TrimSpace(raw)
+
+	if m := legacyPattern.FindStringSubmatch(s); m != nil {
+		return Version{Raw: raw, Kind: KindLegacy, Major: atoi(m[1]), Patch: atoi(m[2]), Build: atoi(m[3]), precision: 3}
+	}
+	if m := corretto8Pattern.FindStringSubmatch(s); m != nil {
+		return Version{Raw: raw, Kind: KindCorretto, Major: 8, Patch: atoi(m[2]), Build: atoi(m[3]), Revision: atoi(m[4]), precision: 3}
+	}
+	if m := correttoPattern.FindStringSubmatch(s); m != nil {
+		return Version{Raw: raw, Kind: KindCorretto, Major: atoi(m[1]), Minor: atoi(m[2]), Patch: atoi(m[3]), Build: atoi(m[4]), Revision: atoi(m[5]), precision: 3}
+	}
+	if m := semverPattern.FindStringSubmatch(s); m != nil && (strings.HasPrefix(s, "v") || m[4] != "") {
+		return Version{Raw: raw, Kind: KindSemver, Major: atoi(m[1]), Minor: atoi(m[2]), Patch: atoi(m[3]), Pre: m[4], precision: 3}
+	}
+	if m := jep223Pattern.FindStringSubmatch(s); m != nil {
+		components := append([]int{atoi(m[1])}, numbers(m[2])...)
+		v := fromComponents(raw, KindJEP223, components)
+		v.Build = atoi(m[3])
+		return v
+	}
+
+	return fromComponents(raw, KindGeneric, numbers(s))
+}
+
+// fromComponents fills the fields from an ordered list of components
+func fromComponents(raw string, kind Kind, components []int) Version {
+	v := Version{Raw: raw, Kind: kind, precision: len(components)}
+	fields := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, n := range components {
+		if i < len(fields) {
+			*fields[i] = n
+		} else {
+			v.Extra = append(v.Extra, n)
+		}
+	}
+	return v
+}
+
+// numbers returns the numeric components of a string
+func numbers(s string) []int {
+	var result []int
+	for _, number := range numberPattern.FindAllString(s, -1) {
+		result = append(result, atoi(number))
+	}
+	return result
+}
+
+// atoi converts a component, saturating numbers too large for an int
+func atoi(s string) int {
+	if s == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return int(^uint(0) >> 1)
+	}
+	return n
+}
+
+// IsNumeric reports whether the version has at least a major number
+func (v Version) IsNumeric() bool {
+	return v.precision > 0
+}
+
+// MajorString returns the major version as a string, or "unknown"
+func (v Version) MajorString() string {
+	if !v.IsNumeric() {
+		return "unknown"
+	}
+	return strconv.Itoa(v.Major)
+}
+
+// String returns the original version string
+func (v Version) String() string {
+	return v.Raw
+}
+
+// component returns the i-th component before the build: Major, Minor, Patch, then Extra
+func (v Version) component(i int) int {
+	switch i {
+	case 0:
+		return v.Major
+	case 1:
+		return v.Minor
+	case 2:
+		return v.Patch
+	}
+	if i-3 < len(v.Extra) {
+		return v.Extra[i-3]
+	}
+	return 0
+}
+
+// Compare returns -1, 0 or 1 depending on whether a is older than, equal to or newer than b.
+// Missing components count as zero, so 17 equals 17.0.0.
+func Compare(a, b Version) int {
+	n := 3 + len(a.Extra)
+	if m := 3 + len(b.Extra); m > n {
+		n = m
+	}
+	for i := 0; i < n; i++ {
+		if c := compareInts(a.component(i), b.component(i)); c != 0 {
+			return c
+		}
+	}
+	if c := compareInts(a.Build, b.Build); c != 0 {
+		return c
+	}
+	if c := compareInts(a.Revision, b.Revision); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.Pre == b.Pre:
+		return 0
+	case a.Pre == "":
+		return 1
+	case b.Pre == "":
+		return -1
+	}
+	return comparePre(a.Pre, b.Pre)
+}
+
+// comparePre orders pre-releases as semver does: identifier by identifier, numbers
+// numerically and before words, a shorter list first (rc.2 < rc.10 < rc.10.1)
+func comparePre(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInts(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInts(len(as), len(bs))
+}
+
+func compareInts(x, y int) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// Less reports whether version string a is older than b
+func Less(a, b string) bool {
+	return Compare(Parse(a), Parse(b)) < 0
+}
+
+// Sort sorts version strings from oldest to newest
+func Sort(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return Less(versions[i], versions[j])
+	})
+}
+
+// hasPrefix reports whether v starts with the components given in prefix (21 matches 21.0.6+7)
+func hasPrefix(v, prefix Version) bool {
+	if !prefix.IsNumeric() {
+		return false
+	}
+	for i := 0; i < prefix.precision; i++ {
+		if v.component(i) != prefix.component(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// next returns the smallest version above every version starting with v, e.g. 21 -> 22, 17.0 -> 17.1
+func next(v Version) Version {
+	components := make([]int, v.precision)
+	for i := range components {
+		components[i] = v.component(i)
+	}
+	if len(components) > 0 {
+		components[len(components)-1]++
+	}
+	return fromComponents("", v.Kind, components)
+}
+
+// Group is a set of versions sharing a major version
+type Group struct {
+	Major    string
+	Versions []string
+}
+
+// GroupByMajor groups versions by major version, groups and versions sorted from
+// oldest to newest. Versions without a major number come last, under "unknown".
+func GroupByMajor(versions []string) []Group {
+	sorted := append([]string(nil), versions...)
+	Sort(sorted)
+
+	var groups []Group
+	var unknown []string
+	for _, raw := range sorted {
+		v := Parse(raw)
+		if !v.IsNumeric() {
+			unknown = append(unknown, raw)
+			continue
+		}
+		major := v.MajorString()
+		if len(groups) == 0 || groups[len(groups)-1].Major != major {
+			groups = append(groups, Group{Major: major})
+		}
+		groups[len(groups)-1].Versions = append(groups[len(groups)-1].Versions, raw)
+	}
+	if len(unknown) > 0 {
+		groups = append(groups, Group{Major: "unknown", Versions: unknown})
+	}
+	return groups
+}
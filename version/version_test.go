@@ -0,0 +1,158 @@
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Version
+	}{
+		{"21.0.6+7", Version{Kind: KindJEP223, Major: 21, Minor: 0, Patch: 6, Build: 7}},
+		{"21.0.6_7", Version{Kind: KindJEP223, Major: 21, Minor: 0, Patch: 6, Build: 7}},
+		{"11.0.24_8", Version{Kind: KindJEP223, Major: 11, Minor: 0, Patch: 24, Build: 8}},
+		{"21.0.4.1+1", Version{Kind: KindJEP223, Major: 21, Minor: 0, Patch: 4, Extra: []int{1}, Build: 1}},
+		{"21", Version{Kind: KindJEP223, Major: 21}},
+		{"8u442b06", Version{Kind: KindLegacy, Major: 8, Patch: 442, Build: 6}},
+		{"8u442-b06", Version{Kind: KindLegacy, Major: 8, Patch: 442, Build: 6}},
+		{"1.8.0_442", Version{Kind: KindLegacy, Major: 8, Patch: 442}},
+		{"1.8.0_442-b06", Version{Kind: KindLegacy, Major: 8, Patch: 442, Build: 6}},
+		{"21.0.6.7.1", Version{Kind: KindCorretto, Major: 21, Minor: 0, Patch: 6, Build: 7, Revision: 1}},
+		{"8.442.06.1", Version{Kind: KindCorretto, Major: 8, Patch: 442, Build: 6, Revision: 1}},
+		{"v22.11.0", Version{Kind: KindSemver, Major: 22, Minor: 11, Patch: 0}},
+		{"23.0.0-rc.1", Version{Kind: KindSemver, Major: 23, Pre: "rc.1"}},
+		{"22.13.1", Version{Kind: KindJEP223, Major: 22, Minor: 13, Patch: 1}},
+		{"jdk-17-ea", Version{Kind: KindGeneric, Major: 17}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.raw, func(t *testing.T) {
+			got := Parse(test.raw)
+			got.precision = 0
+			test.want.Raw = test.raw
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", test.raw, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+	v := Parse("current")
+	if v.IsNumeric() {
+		t.Errorf("Parse(%q).IsNumeric() = true, want false", "current")
+	}
+	if got := v.MajorString(); got != "unknown" {
+		t.Errorf("MajorString() = %q, want %q", got, "unknown")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// JEP 223, whatever the build separator
+		{"21.0.6+7", "21.0.6_7", 0},
+		{"17.0.13+11", "17.0.9+9", 1},
+		{"21.0.6+7", "21.0.6+10", -1},
+		{"21.0.4.1+1", "21.0.4+7", 1},
+		{"17", "17.0.0", 0},
+		{"11.0.24_8", "17.0.1+12", -1},
+
+		// Legacy JDK 8
+		{"8u442b06", "8u442-b06", 0},
+		{"8u442-b06", "1.8.0_442-b06", 0},
+		{"8u442b06", "8u432b06", 1},
+		{"8u442b06", "8u442b07", -1},
+		{"1.8.0_442", "11.0.24_8", -1},
+
+		// Corretto
+		{"21.0.6.7.1", "21.0.6.7.2", -1},
+		{"21.0.6.7.1", "21.0.5.11.1", 1},
+		{"8.442.06.1", "8.432.06.1", 1},
+		{"8.442.06.1", "8.442.06.2", -1},
+
+		// Semver and pre-releases
+		{"v22.11.0", "22.11.0", 0},
+		{"22.13.1", "22.9.0", 1},
+		{"23.0.0-rc.1", "23.0.0", -1},
+		{"23.0.0-rc.1", "22.13.1", 1},
+		{"23.0.0-rc.1", "23.0.0-rc.2", -1},
+		{"23.0.0-rc.2", "23.0.0-rc.10", -1},
+		{"23.0.0-rc.10", "23.0.0-rc.10.1", -1},
+		{"23.0.0-beta", "23.0.0-rc.1", -1},
+		{"23.0.0-1", "23.0.0-alpha", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			if got := Compare(Parse(test.a), Parse(test.b)); got != test.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+			}
+			if got := Compare(Parse(test.b), Parse(test.a)); got != -test.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", test.b, test.a, got, -test.want)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	versions := []string{"17.0.9+9", "8u442b06", "17.0.13+11", "21.0.6+7", "11.0.24_8", "8u432b06"}
+	Sort(versions)
+
+	want := []string{"8u432b06", "8u442b06", "11.0.24_8", "17.0.9+9", "17.0.13+11", "21.0.6+7"}
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("Sort() = %v, want %v", versions, want)
+	}
+}
+
+func TestHasPrefix(t *testing.T) {
+	tests := []struct {
+		version, prefix string
+		want            bool
+	}{
+		{"21.0.6+7", "21", true},
+		{"21.0.6_7", "21.0", true},
+		{"21.0.6+7", "21.0.6", true},
+		{"21.0.6+7", "21.0.5", false},
+		{"17.0.13+11", "17.1", false},
+		{"210.0.1", "21", false},
+		{"8u442b06", "8", true},
+		{"1.8.0_442", "8", true},
+		{"1.8.0_442", "1", false},
+		{"17.0.13.11.1", "17.0", true},
+		{"22.13.1", "22", true},
+		{"v22.13.1", "22.13", true},
+		{"current", "21", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version+" "+test.prefix, func(t *testing.T) {
+			if got := hasPrefix(Parse(test.version), Parse(test.prefix)); got != test.want {
+				t.Errorf("hasPrefix(%q, %q) = %v, want %v", test.version, test.prefix, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGroupByMajor(t *testing.T) {
+	versions := []string{"21.0.6+7", "8u442b06", "17.0.13+11", "current", "17.0.9+9", "11.0.24_8", "8.432.06.1"}
+
+	want := []Group{
+		{Major: "8", Versions: []string{"8.432.06.1", "8u442b06"}},
+		{Major: "11", Versions: []string{"11.0.24_8"}},
+		{Major: "17", Versions: []string{"17.0.9+9", "17.0.13+11"}},
+		{Major: "21", Versions: []string{"21.0.6+7"}},
+		{Major: "unknown", Versions: []string{"current"}},
+	}
+	if got := GroupByMajor(versions); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupByMajor() = %+v, want %+v", got, want)
+	}
+
+	if got := GroupByMajor(nil); got != nil {
+		t.Errorf("GroupByMajor(nil) = %+v, want nil", got)
+	}
+}